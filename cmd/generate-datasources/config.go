@@ -39,6 +39,35 @@ type Entity struct {
 	// E.g. from https://github.com/lxc/incus/blob/3da8fcd06c4f7ee3cb9388127e6071244db7ac8f/client/incus_networks.go#L104
 	IncusGetMethod string `yaml:"incus-get-method"`
 
+	// Name of a function of the package to get the resource, which is used
+	// instead of `incus-get-method` for resources not supported by the Incus
+	// client. The function is called with the Incus client followed by the
+	// arguments otherwise passed to `incus-get-method`.
+	IncusGetFunction string `yaml:"incus-get-function"`
+
+	// Method of the Incus client to list all the resources, e.g.
+	// `GetNetworks`. If set, an additional plural data source is generated,
+	// which returns the resources as a map keyed by their name.
+	//
+	// The method is called with the arguments from `incus-list-method-args`,
+	// followed by the name of the parent (if any) and the filter expressions
+	// (if `has-list-filter` is set to `true`).
+	IncusListMethod string `yaml:"incus-list-method"`
+
+	// List of Go expressions passed as leading arguments to the method from
+	// `incus-list-method`, e.g. `api.InstanceTypeAny`. Expressions referencing
+	// the package `api` are supported.
+	IncusListMethodArgs []string `yaml:"incus-list-method-args"`
+
+	// If the method from `incus-list-method` accepts a list of filter
+	// expressions, which are evaluated by the server.
+	// If this is the case, `has-list-filter` needs to be set to `true`.
+	HasListFilter bool `yaml:"has-list-filter"`
+
+	// Name of the plural data source. Defaults to the name of the resource
+	// with an `s` appended, e.g. `networks`.
+	PluralName string `yaml:"plural-name"`
+
 	// Name of the parent entity, if any.
 	// Resources like network forwards have a parent, in this case a network.
 	// If this is the case, the name of the parent needs to be specidied.
@@ -64,6 +93,11 @@ type Entity struct {
 	// `has-no-config` needs to be set to `true`.
 	HasNoConfig bool `yaml:"has-no-config"`
 
+	// If a resource has no description attribute.
+	// Most resources do have a description attribute. If this is not the case,
+	// `has-no-description` needs to be set to `true`.
+	HasNoDescription bool `yaml:"has-no-description"`
+
 	// If a resource has a location, mutual exclusive with has-locations.
 	// Some resources are location aware. If this is the case for a resource,
 	// `has-location` needs to be set to `true`.
@@ -93,7 +127,7 @@ type Entity struct {
 	//   * `project`
 	//   * `target`
 	//   * `remote`
-	//   * `description`
+	//   * `description` (if `has-no-description` is not set to `true`)
 	//   * `config` (if `has-no-config` is not set to `true`)
 	//   * `status` (if `has-no-status` is not set to `true`)
 	//   * `location` (if `has-location` is set to `true`)
@@ -158,6 +192,14 @@ type ExtraAttribute struct {
 
 	// Description of the extra attribute. This is added to the documentation.
 	Description string `yaml:"description"`
+
+	// Value used in the example of the documentation, if the attribute is
+	// used as `extra-id-attribute`. Defaults to `custom`.
+	ExampleValue string `yaml:"example-value"`
+
+	// Name of the attribute in the JSON representation of the API object, if
+	// it differs from `name`. Only used for the attributes of objects.
+	JSONName string `yaml:"json-name"`
 }
 
 func (c *Config) LoadConfig(path string) error {
@@ -46,6 +46,10 @@ var targets = []struct {
 	// Type of the target file, e.g. `go`. Mainly used to control the post
 	// processing, e.g. for `go` files automated formatting is applied.
 	TargetFileType FileType
+
+	// If true, the target is only generated for entities with
+	// `incus-list-method` set, e.g. for the plural data sources.
+	ListOnly bool
 }{
 	{
 		TemplateName:   "datasource.go.gotmpl",
@@ -57,6 +61,18 @@ var targets = []struct {
 		TargetName:     "docs/data-sources/{{ .Name }}.md",
 		TargetFileType: FileTypeMarkdown,
 	},
+	{
+		TemplateName:   "datasource_list.go.gotmpl",
+		TargetName:     "internal/{{ .PackageName }}/datasource_{{ .PluralName }}_gen.go",
+		TargetFileType: FileTypeGo,
+		ListOnly:       true,
+	},
+	{
+		TemplateName:   "docs_list.md.gotmpl",
+		TargetName:     "docs/data-sources/{{ .PluralName }}.md",
+		TargetFileType: FileTypeMarkdown,
+		ListOnly:       true,
+	},
 }
 
 // globalTarges is a list of files, which are only generated once and not
@@ -104,6 +120,25 @@ type entityArgs struct {
 	// Value from `incus-get-method`
 	IncusGetMethod string
 
+	// Value from `incus-get-function`
+	IncusGetFunction string
+
+	// `true`, if `incus-list-method` is not empty.
+	HasList bool
+
+	// Value from `incus-list-method`.
+	IncusListMethod string
+
+	// Value from `incus-list-method-args`.
+	IncusListMethodArgs []string
+
+	// Value from `has-list-filter`.
+	HasListFilter bool
+
+	// PluralName is the name of the plural data source, which is either the
+	// value from `plural-name` or the name with an `s` appended.
+	PluralName string
+
 	// `true`, if `partent` is not empty.
 	HasParent bool
 
@@ -119,6 +154,9 @@ type entityArgs struct {
 	// Inverted value from `has-no-status`.
 	HasStatus bool
 
+	// Inverted value from `has-no-description`.
+	HasDescription bool
+
 	// Inverted value from `has-no-config`.
 	HasConfig bool
 
@@ -168,6 +206,10 @@ func main() {
 			cfg[name].ObjectNamePropertyDefaultValue = "default"
 		}
 
+		if entity.PluralName == "" {
+			cfg[name].PluralName = name + "s"
+		}
+
 		slog.DebugContext(ctx, "entity config", slog.String("name", name), slog.Any("config", entity))
 	}
 
@@ -192,11 +234,18 @@ func main() {
 			ObjectNamePropertyName:         entity.ObjectNamePropertyName,
 			ObjectNamePropertyDefaultValue: entity.ObjectNamePropertyDefaultValue,
 			IncusGetMethod:                 entity.IncusGetMethod,
+			IncusGetFunction:               entity.IncusGetFunction,
+			HasList:                        entity.IncusListMethod != "",
+			IncusListMethod:                entity.IncusListMethod,
+			IncusListMethodArgs:            entity.IncusListMethodArgs,
+			HasListFilter:                  entity.HasListFilter,
+			PluralName:                     entity.PluralName,
 			HasParent:                      entity.ParentName != "",
 			ParentName:                     entity.ParentName,
 			HasProject:                     !entity.HasNoProject,
 			HasTarget:                      entity.HasTarget,
 			HasStatus:                      !entity.HasNoStatus,
+			HasDescription:                 !entity.HasNoDescription,
 			HasConfig:                      !entity.HasNoConfig,
 			HasLocation:                    entity.HasLocation,
 			HasLocations:                   entity.HasLocations,
@@ -216,6 +265,10 @@ func main() {
 				continue
 			}
 
+			if target.ListOnly && !args.HasList {
+				continue
+			}
+
 			slog.InfoContext(ctx, "generating", slog.String("name", args.Name), slog.String("template", target.TemplateName))
 
 			filename := strings.Builder{}
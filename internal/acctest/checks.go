@@ -2,12 +2,15 @@ package acctest
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/lxc/incus/v7/shared/api"
 
 	"github.com/lxc/terraform-provider-incus/internal/utils"
 )
@@ -130,6 +133,33 @@ func PreCheckStandalone(t *testing.T) {
 	}
 }
 
+// GetClusterMember returns the last cluster member in alphabetical order.
+// Tests which reconfigure the member must restore its configuration.
+func GetClusterMember(t *testing.T) api.ClusterMember {
+	t.Helper()
+
+	p := testProvider()
+	server, err := p.InstanceServer("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := server.GetClusterMembers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(members) == 0 {
+		t.Fatal("No cluster members found")
+	}
+
+	slices.SortFunc(members, func(a api.ClusterMember, b api.ClusterMember) int {
+		return strings.Compare(a.ServerName, b.ServerName)
+	})
+
+	return members[len(members)-1]
+}
+
 func PreCheckX86_64(t *testing.T) {
 	t.Helper()
 
@@ -229,3 +259,47 @@ func TestCheckResourceAttrInLookup(name string, key string, lookup map[string]st
 		return nil
 	})
 }
+
+// TestCheckInstanceFileContent ensures the file at the given path within the
+// instance has the expected content.
+func TestCheckInstanceFileContent(t *testing.T, instanceName string, path string, content string) resource.TestCheckFunc {
+	t.Helper()
+
+	return func(_ *terraform.State) error {
+		p := testProvider()
+		client, err := p.InstanceServer("", "", "")
+		if err != nil {
+			return err
+		}
+
+		reader, _, err := client.GetInstanceFile(instanceName, path)
+		if err != nil {
+			return fmt.Errorf("Failed to retrieve file %q from instance %q: %v", path, instanceName, err)
+		}
+
+		defer func() { _ = reader.Close() }()
+
+		got, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+
+		if string(got) != content {
+			return fmt.Errorf("File %q in instance %q has content %q, expected %q", path, instanceName, string(got), content)
+		}
+
+		return nil
+	}
+}
+
+// TestCheckFileExists ensures a local file exists at the given path.
+func TestCheckFileExists(path string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		_, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("File %q does not exist: %v", path, err)
+		}
+
+		return nil
+	}
+}
@@ -1,8 +1,10 @@
 package acctest
 
 import (
+	"strings"
 	"testing"
 
+	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/shared/api"
 )
 
@@ -64,3 +66,25 @@ func PreConfigAccTestServerConfig(t *testing.T, shouldRemain bool) {
 		}
 	})
 }
+
+// PreConfigInstanceFile overwrites the file at the given path in the instance
+// with the given content, e.g. to simulate changes made outside of Terraform.
+func PreConfigInstanceFile(t *testing.T, instanceName string, path string, content string) {
+	t.Helper()
+
+	p := testProvider()
+	client, err := p.InstanceServer("", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.CreateInstanceFile(instanceName, path, incus.InstanceFileArgs{
+		Type:      "file",
+		Mode:      0o644,
+		WriteMode: "overwrite",
+		Content:   strings.NewReader(content),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
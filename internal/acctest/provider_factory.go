@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
 
 	"github.com/lxc/terraform-provider-incus/internal/provider"
@@ -60,3 +61,11 @@ func testProvider() *provider_config.IncusProviderConfig {
 var ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"incus": providerserver.NewProtocol6WithError(provider.NewIncusProvider("test")()),
 }
+
+// ProtoV6ProviderFactoriesWithEcho are used to instantiate the provider
+// together with the echo provider, which exposes the result of ephemeral
+// resources in the state of its "echo" resource during acceptance testing.
+var ProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"incus": providerserver.NewProtocol6WithError(provider.NewIncusProvider("test")()),
+	"echo":  echoprovider.NewProviderServer(),
+}
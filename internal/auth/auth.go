@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// The Incus client provides no methods for the fine-grained authorization
+// API, therefore its endpoints are queried directly.
+
+// PermissionModel represents a permission of an authorization group.
+type PermissionModel struct {
+	Entitlement     types.String `tfsdk:"entitlement"`
+	EntityType      types.String `tfsdk:"entity_type"`
+	EntityReference types.String `tfsdk:"entity_reference"`
+}
+
+// permissionObjectType is the type of the elements of the permission block.
+var permissionObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"entitlement":      types.StringType,
+		"entity_type":      types.StringType,
+		"entity_reference": types.StringType,
+	},
+}
+
+func authGroupPath(name string) string {
+	return "/1.0/auth/groups/" + url.PathEscape(name)
+}
+
+func identityProviderGroupPath(name string) string {
+	return "/1.0/auth/identity-provider-groups/" + url.PathEscape(name)
+}
+
+func identityPath(authenticationMethod string, nameOrID string) string {
+	return "/1.0/auth/identities/" + url.PathEscape(authenticationMethod) + "/" + url.PathEscape(nameOrID)
+}
+
+// queryStruct sends a GET request to the given path and decodes the
+// response into target. The ETag of the response is returned.
+func queryStruct(server incus.InstanceServer, path string, target any) (string, error) {
+	resp, etag, err := server.RawQuery(http.MethodGet, path, nil, "")
+	if err != nil {
+		return "", err
+	}
+
+	err = resp.MetadataAsStruct(target)
+	if err != nil {
+		return "", err
+	}
+
+	return etag, nil
+}
+
+// getAuthGroup returns the authorization group with the given name.
+func getAuthGroup(server incus.InstanceServer, name string) (*api.AuthGroup, string, error) {
+	group := api.AuthGroup{}
+	etag, err := queryStruct(server, authGroupPath(name), &group)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &group, etag, nil
+}
+
+func createAuthGroup(server incus.InstanceServer, group api.AuthGroupsPost) error {
+	_, _, err := server.RawQuery(http.MethodPost, "/1.0/auth/groups", group, "")
+	return err
+}
+
+func updateAuthGroup(server incus.InstanceServer, name string, group api.AuthGroupPut, etag string) error {
+	_, _, err := server.RawQuery(http.MethodPut, authGroupPath(name), group, etag)
+	return err
+}
+
+func deleteAuthGroup(server incus.InstanceServer, name string) error {
+	_, _, err := server.RawQuery(http.MethodDelete, authGroupPath(name), nil, "")
+	return err
+}
+
+// getIdentityProviderGroup returns the identity provider group with the
+// given name.
+func getIdentityProviderGroup(server incus.InstanceServer, name string) (*api.IdentityProviderGroup, string, error) {
+	group := api.IdentityProviderGroup{}
+	etag, err := queryStruct(server, identityProviderGroupPath(name), &group)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &group, etag, nil
+}
+
+func createIdentityProviderGroup(server incus.InstanceServer, group api.IdentityProviderGroup) error {
+	_, _, err := server.RawQuery(http.MethodPost, "/1.0/auth/identity-provider-groups", group, "")
+	return err
+}
+
+func updateIdentityProviderGroup(server incus.InstanceServer, name string, group api.IdentityProviderGroupPut, etag string) error {
+	_, _, err := server.RawQuery(http.MethodPut, identityProviderGroupPath(name), group, etag)
+	return err
+}
+
+func deleteIdentityProviderGroup(server incus.InstanceServer, name string) error {
+	_, _, err := server.RawQuery(http.MethodDelete, identityProviderGroupPath(name), nil, "")
+	return err
+}
+
+// getIdentity returns the identity with the given authentication method and
+// name or identifier.
+func getIdentity(server incus.InstanceServer, authenticationMethod string, nameOrID string) (*api.Identity, string, error) {
+	identity := api.Identity{}
+	etag, err := queryStruct(server, identityPath(authenticationMethod, nameOrID), &identity)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &identity, etag, nil
+}
+
+func updateIdentity(server incus.InstanceServer, authenticationMethod string, nameOrID string, identity api.IdentityPut, etag string) error {
+	_, _, err := server.RawQuery(http.MethodPut, identityPath(authenticationMethod, nameOrID), identity, etag)
+	return err
+}
+
+// ToPermissionList converts permissions of type types.Set into []api.Permission.
+func ToPermissionList(ctx context.Context, permissionSet types.Set) ([]api.Permission, diag.Diagnostics) {
+	if permissionSet.IsNull() || permissionSet.IsUnknown() {
+		return []api.Permission{}, nil
+	}
+
+	models := make([]PermissionModel, 0, len(permissionSet.Elements()))
+	diags := permissionSet.ElementsAs(ctx, &models, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	permissions := make([]api.Permission, 0, len(models))
+	for _, m := range models {
+		permissions = append(permissions, api.Permission{
+			Entitlement:     m.Entitlement.ValueString(),
+			EntityType:      m.EntityType.ValueString(),
+			EntityReference: m.EntityReference.ValueString(),
+		})
+	}
+
+	return permissions, nil
+}
+
+// ToPermissionSetType converts []api.Permission into permissions of type
+// types.Set.
+func ToPermissionSetType(ctx context.Context, permissions []api.Permission) (types.Set, diag.Diagnostics) {
+	models := make([]PermissionModel, 0, len(permissions))
+	for _, p := range permissions {
+		models = append(models, PermissionModel{
+			Entitlement:     types.StringValue(p.Entitlement),
+			EntityType:      types.StringValue(p.EntityType),
+			EntityReference: types.StringValue(p.EntityReference),
+		})
+	}
+
+	return types.SetValueFrom(ctx, permissionObjectType, models)
+}
+
+// ToGroupSet converts groups of type types.Set into []string.
+func ToGroupSet(ctx context.Context, groupSet types.Set) ([]string, diag.Diagnostics) {
+	groups := make([]string, 0, len(groupSet.Elements()))
+	diags := groupSet.ElementsAs(ctx, &groups, false)
+
+	return groups, diags
+}
+
+// ToGroupSetType converts []string into groups of type types.Set.
+func ToGroupSetType(ctx context.Context, groups []string) (types.Set, diag.Diagnostics) {
+	if len(groups) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+
+	return types.SetValueFrom(ctx, types.StringType, groups)
+}
@@ -0,0 +1,240 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type AuthGroupDataSourceModel struct {
+	Name types.String `tfsdk:"name"`
+
+	Remote types.String `tfsdk:"remote"`
+
+	Description types.String `tfsdk:"description"`
+
+	// Extra attributes.
+	Permissions            types.List `tfsdk:"permissions"`
+	IdentityProviderGroups types.List `tfsdk:"identity_provider_groups"`
+}
+
+type AuthGroupDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewAuthGroupDataSource() datasource.DataSource {
+	return &AuthGroupDataSource{}
+}
+
+func (d *AuthGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_auth_group", req.ProviderTypeName)
+}
+
+func (d *AuthGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+
+			// Extra attributes.
+			"permissions": schema.ListAttribute{
+				Optional: true,
+				Computed: true,
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"entitlement":      types.StringType,
+						"entity_type":      types.StringType,
+						"entity_reference": types.StringType,
+					},
+				},
+			},
+
+			"identity_provider_groups": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *AuthGroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *AuthGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state AuthGroupDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := ""
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	authGroupName := state.Name.ValueString()
+	authGroup, _, err := getAuthGroup(server, authGroupName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve existing auth group %q", authGroupName), err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(authGroup.Name)
+	state.Description = types.StringValue(authGroup.Description)
+
+	// Extra attributes.
+	state.Permissions, diags = toAuthGroupPermissionsListTypeValue(ctx, authGroup.Permissions)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	state.IdentityProviderGroups, diags = toAuthGroupIdentityProviderGroupsListTypeValue(ctx, authGroup.IdentityProviderGroups)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getAuthGroupPermissionsListType() types.ListType {
+	return types.ListType{
+		ElemType: types.MapType{
+			ElemType: types.ObjectType{
+				AttrTypes: map[string]attr.Type{
+					"entitlement":      types.StringType,
+					"entity_type":      types.StringType,
+					"entity_reference": types.StringType,
+				},
+			},
+		},
+	}
+}
+
+func toAuthGroupPermissionsListTypeValue(ctx context.Context, in any) (types.List, diag.Diagnostics) {
+	authgroupPermissionsListType := getAuthGroupPermissionsListType()
+	nilList := types.ListNull(authgroupPermissionsListType)
+
+	authgroupPermissionsItems, err := common.ToMapStringAnySlice(in)
+	if err != nil {
+		return nilList, diag.Diagnostics{diag.NewErrorDiagnostic("to slice of maps conversion failed", err.Error())}
+	}
+
+	authgroupPermissionsList := make([]attr.Value, 0, len(authgroupPermissionsItems))
+	for _, authgroupPermissionsItem := range authgroupPermissionsItems {
+		objectValue, diags := toAuthGroupPermissionsObjectTypeValue(ctx, authgroupPermissionsItem)
+		if diags.HasError() {
+			return nilList, diags
+		}
+
+		authgroupPermissionsList = append(authgroupPermissionsList, objectValue)
+	}
+
+	return types.ListValue(authgroupPermissionsListType, authgroupPermissionsList)
+}
+
+func getAuthGroupPermissionsObjectType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"entitlement":      types.StringType,
+			"entity_type":      types.StringType,
+			"entity_reference": types.StringType,
+		},
+	}
+}
+
+func toAuthGroupPermissionsObjectTypeValue(ctx context.Context, in map[string]any) (obj basetypes.ObjectValue, diags diag.Diagnostics) {
+	authgroupPermissionsObjectType := getAuthGroupPermissionsObjectType()
+	nilObject := types.ObjectNull(authgroupPermissionsObjectType.AttrTypes)
+
+	res := map[string]attr.Value{}
+	entitlementStringValue := types.StringNull()
+	entitlementAny, ok := in["entitlement"]
+	if ok {
+		entitlementString, ok := entitlementAny.(string)
+		if ok {
+			entitlementStringValue = types.StringValue(entitlementString)
+		}
+	}
+	res["entitlement"] = entitlementStringValue
+
+	entityTypeStringValue := types.StringNull()
+	entityTypeAny, ok := in["entity_type"]
+	if ok {
+		entityTypeString, ok := entityTypeAny.(string)
+		if ok {
+			entityTypeStringValue = types.StringValue(entityTypeString)
+		}
+	}
+	res["entity_type"] = entityTypeStringValue
+
+	entityReferenceStringValue := types.StringNull()
+	entityReferenceAny, ok := in["url"]
+	if ok {
+		entityReferenceString, ok := entityReferenceAny.(string)
+		if ok {
+			entityReferenceStringValue = types.StringValue(entityReferenceString)
+		}
+	}
+	res["entity_reference"] = entityReferenceStringValue
+
+	obj, diags = types.ObjectValue(authgroupPermissionsObjectType.AttrTypes, res)
+	if diags.HasError() {
+		return nilObject, diags
+	}
+
+	return obj, nil
+}
+
+func getAuthGroupIdentityProviderGroupsListType() types.ListType {
+	return types.ListType{
+		ElemType: types.StringType,
+	}
+}
+
+func toAuthGroupIdentityProviderGroupsListTypeValue(ctx context.Context, in any) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, in)
+}
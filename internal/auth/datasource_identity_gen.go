@@ -0,0 +1,144 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type IdentityDataSourceModel struct {
+	Name                 types.String `tfsdk:"name"`
+	AuthenticationMethod types.String `tfsdk:"authentication_method"`
+
+	Remote types.String `tfsdk:"remote"`
+
+	// Extra attributes.
+	Identifier types.String `tfsdk:"identifier"`
+	Type       types.String `tfsdk:"type"`
+	Groups     types.List   `tfsdk:"groups"`
+}
+
+type IdentityDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewIdentityDataSource() datasource.DataSource {
+	return &IdentityDataSource{}
+}
+
+func (d *IdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_identity", req.ProviderTypeName)
+}
+
+func (d *IdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+
+			"authentication_method": schema.StringAttribute{
+				Required: true,
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			// Extra attributes.
+			"identifier": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+
+			"type": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+
+			"groups": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *IdentityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *IdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state IdentityDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := ""
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	authenticationMethodName := state.AuthenticationMethod.ValueString()
+
+	identityName := state.Name.ValueString()
+	identity, _, err := getIdentity(server, authenticationMethodName, identityName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve existing identity %q", identityName), err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(identity.Name)
+	state.AuthenticationMethod = types.StringValue(identity.AuthenticationMethod)
+
+	// Extra attributes.
+	state.Identifier = types.StringValue(identity.Identifier)
+	state.Type = types.StringValue(identity.Type)
+	state.Groups, diags = toIdentityGroupsListTypeValue(ctx, identity.Groups)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getIdentityGroupsListType() types.ListType {
+	return types.ListType{
+		ElemType: types.StringType,
+	}
+}
+
+func toIdentityGroupsListTypeValue(ctx context.Context, in any) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, in)
+}
@@ -0,0 +1,122 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type IdentityProviderGroupDataSourceModel struct {
+	Name types.String `tfsdk:"name"`
+
+	Remote types.String `tfsdk:"remote"`
+
+	// Extra attributes.
+	Groups types.List `tfsdk:"groups"`
+}
+
+type IdentityProviderGroupDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewIdentityProviderGroupDataSource() datasource.DataSource {
+	return &IdentityProviderGroupDataSource{}
+}
+
+func (d *IdentityProviderGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_identity_provider_group", req.ProviderTypeName)
+}
+
+func (d *IdentityProviderGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			// Extra attributes.
+			"groups": schema.ListAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *IdentityProviderGroupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *IdentityProviderGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state IdentityProviderGroupDataSourceModel
+	var diags diag.Diagnostics
+
+	diags = req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := ""
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	identityProviderGroupName := state.Name.ValueString()
+	identityProviderGroup, _, err := getIdentityProviderGroup(server, identityProviderGroupName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve existing identity provider group %q", identityProviderGroupName), err.Error())
+		return
+	}
+
+	state.Name = types.StringValue(identityProviderGroup.Name)
+
+	// Extra attributes.
+	state.Groups, diags = toIdentityProviderGroupGroupsListTypeValue(ctx, identityProviderGroup.Groups)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getIdentityProviderGroupGroupsListType() types.ListType {
+	return types.ListType{
+		ElemType: types.StringType,
+	}
+}
+
+func toIdentityProviderGroupGroupsListTypeValue(ctx context.Context, in any) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.StringType, in)
+}
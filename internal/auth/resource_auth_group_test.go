@@ -0,0 +1,142 @@
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccAuthGroup_basic(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthGroup_basic(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "name", groupName),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "description", ""),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "permission.#", "0"),
+				),
+			},
+			{
+				ResourceName:                         "incus_auth_group.group1",
+				ImportState:                          true,
+				ImportStateId:                        groupName,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func TestAccAuthGroup_permissions(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+	projectName := petname.Generate(1, "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthGroup_permissions(groupName, projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "name", groupName),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "description", "Developers"),
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "permission.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("incus_auth_group.group1", "permission.*", map[string]string{
+						"entitlement":      "operator",
+						"entity_type":      "project",
+						"entity_reference": "/1.0/projects/" + projectName,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("incus_auth_group.group1", "permission.*", map[string]string{
+						"entitlement":      "can_view",
+						"entity_type":      "server",
+						"entity_reference": "/1.0",
+					}),
+				),
+			},
+			{
+				// Remove a permission.
+				Config: testAccAuthGroup_permission(groupName, projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_auth_group.group1", "permission.#", "1"),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "name", groupName),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "description", "Developers"),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "permissions.0.entitlement", "operator"),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "permissions.0.entity_type", "project"),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "permissions.0.entity_reference", "/1.0/projects/"+projectName),
+				),
+			},
+		},
+	})
+}
+
+func testAccAuthGroup_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_auth_group" "group1" {
+  name = "%s"
+}
+`, name)
+}
+
+func testAccAuthGroup_permissions(name string, projectName string) string {
+	return fmt.Sprintf(`
+resource "incus_project" "project1" {
+  name = "%[2]s"
+}
+
+resource "incus_auth_group" "group1" {
+  name        = "%[1]s"
+  description = "Developers"
+
+  permission {
+    entitlement      = "operator"
+    entity_type      = "project"
+    entity_reference = "/1.0/projects/${incus_project.project1.name}"
+  }
+
+  permission {
+    entitlement      = "can_view"
+    entity_type      = "server"
+    entity_reference = "/1.0"
+  }
+}
+`, name, projectName)
+}
+
+func testAccAuthGroup_permission(name string, projectName string) string {
+	return fmt.Sprintf(`
+resource "incus_project" "project1" {
+  name = "%[2]s"
+}
+
+resource "incus_auth_group" "group1" {
+  name        = "%[1]s"
+  description = "Developers"
+
+  permission {
+    entitlement      = "operator"
+    entity_type      = "project"
+    entity_reference = "/1.0/projects/${incus_project.project1.name}"
+  }
+}
+
+data "incus_auth_group" "group1" {
+  name = incus_auth_group.group1.name
+}
+`, name, projectName)
+}
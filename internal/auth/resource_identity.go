@@ -0,0 +1,325 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type IdentityModel struct {
+	AuthenticationMethod types.String `tfsdk:"authentication_method"`
+	Name                 types.String `tfsdk:"name"`
+	Remote               types.String `tfsdk:"remote"`
+	Groups               types.Set    `tfsdk:"groups"`
+
+	// Computed.
+	Identifier types.String `tfsdk:"identifier"`
+	Type       types.String `tfsdk:"type"`
+}
+
+// IdentityResource manages the authorization groups of an identity.
+// Identities are created by Incus when a client authenticates, therefore
+// the resource adopts an existing identity and only manages its groups.
+type IdentityResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewIdentityResource() resource.Resource {
+	return &IdentityResource{}
+}
+
+func (r *IdentityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_identity", req.ProviderTypeName)
+}
+
+func (r *IdentityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"authentication_method": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("tls", "oidc"),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"groups": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					// Prevent empty values.
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			// Computed.
+
+			"identifier": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *IdentityResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// Create adopts an existing identity and sets its groups.
+func (r *IdentityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan IdentityModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	authMethod := plan.AuthenticationMethod.ValueString()
+	identityName := plan.Name.ValueString()
+	_, etag, err := getIdentity(server, authMethod, identityName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Identity %q not found", identityName),
+				fmt.Sprintf("The %s identity %q does not exist. Identities are created by Incus once the client authenticated and can only be adopted.", authMethod, identityName),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve identity %q", identityName), err.Error())
+		return
+	}
+
+	diags = r.updateGroups(ctx, server, plan, etag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state IdentityModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *IdentityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan IdentityModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	identityName := plan.Name.ValueString()
+	_, etag, err := getIdentity(server, plan.AuthenticationMethod.ValueString(), identityName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve identity %q", identityName), err.Error())
+		return
+	}
+
+	diags = r.updateGroups(ctx, server, plan, etag)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the identity from all groups. The identity itself is kept,
+// as it was not created by the resource.
+func (r *IdentityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state IdentityModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	authMethod := state.AuthenticationMethod.ValueString()
+	identityName := state.Name.ValueString()
+	_, etag, err := getIdentity(server, authMethod, identityName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve identity %q", identityName), err.Error())
+		return
+	}
+
+	err = updateIdentity(server, authMethod, identityName, api.IdentityPut{Groups: []string{}}, etag)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove identity %q from its groups", identityName), err.Error())
+	}
+}
+
+func (r *IdentityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "identity",
+		RequiredFields: []string{"authentication_method", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// updateGroups sets the groups of the identity to the planned groups.
+func (r *IdentityResource) updateGroups(ctx context.Context, server incus.InstanceServer, plan IdentityModel, etag string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	groups, groupDiags := ToGroupSet(ctx, plan.Groups)
+	diags.Append(groupDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	identityName := plan.Name.ValueString()
+	err := updateIdentity(server, plan.AuthenticationMethod.ValueString(), identityName, api.IdentityPut{Groups: groups}, etag)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to update identity %q", identityName), err.Error())
+	}
+
+	return diags
+}
+
+// SyncState fetches the server's current state for an identity and updates
+// the provided model. It then applies this updated model as the new state
+// in Terraform.
+func (r *IdentityResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m IdentityModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	identityName := m.Name.ValueString()
+	identity, _, err := getIdentity(server, m.AuthenticationMethod.ValueString(), identityName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve identity %q", identityName), err.Error())
+		return respDiags
+	}
+
+	groups, diags := ToGroupSetType(ctx, identity.Groups)
+	respDiags.Append(diags...)
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	// The name is kept as configured, since the identity can also be
+	// referenced by its identifier.
+	m.AuthenticationMethod = types.StringValue(identity.AuthenticationMethod)
+	m.Groups = groups
+	m.Identifier = types.StringValue(identity.Identifier)
+	m.Type = types.StringValue(identity.Type)
+
+	return tfState.Set(ctx, &m)
+}
@@ -0,0 +1,68 @@
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccIdentityProviderGroup_basic(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+	identityProviderGroupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentityProviderGroup_basic(groupName, identityProviderGroupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp1", "name", identityProviderGroupName),
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp1", "groups.#", "1"),
+					resource.TestCheckResourceAttr("incus_identity_provider_group.idp1", "groups.0", groupName),
+					resource.TestCheckResourceAttr("data.incus_identity_provider_group.idp1", "groups.#", "1"),
+					resource.TestCheckResourceAttr("data.incus_identity_provider_group.idp1", "groups.0", groupName),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "identity_provider_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.incus_auth_group.group1", "identity_provider_groups.0", identityProviderGroupName),
+				),
+			},
+			{
+				ResourceName:                         "incus_identity_provider_group.idp1",
+				ImportState:                          true,
+				ImportStateId:                        identityProviderGroupName,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "name",
+			},
+		},
+	})
+}
+
+func testAccIdentityProviderGroup_basic(groupName string, identityProviderGroupName string) string {
+	return fmt.Sprintf(`
+resource "incus_auth_group" "group1" {
+  name = "%[1]s"
+}
+
+resource "incus_identity_provider_group" "idp1" {
+  name   = "%[2]s"
+  groups = [incus_auth_group.group1.name]
+}
+
+data "incus_identity_provider_group" "idp1" {
+  name = incus_identity_provider_group.idp1.name
+}
+
+data "incus_auth_group" "group1" {
+  name = incus_auth_group.group1.name
+
+  depends_on = [incus_identity_provider_group.idp1]
+}
+`, groupName, identityProviderGroupName)
+}
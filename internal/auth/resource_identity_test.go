@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccIdentity_tls(t *testing.T) {
+	groupName := petname.Generate(2, "-")
+	certificateName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIdentity_tls(groupName, certificateName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_identity.identity1", "authentication_method", "tls"),
+					resource.TestCheckResourceAttrPair("incus_identity.identity1", "identifier", "incus_certificate.cert1", "fingerprint"),
+					resource.TestCheckResourceAttr("incus_identity.identity1", "groups.#", "1"),
+					resource.TestCheckResourceAttr("incus_identity.identity1", "groups.0", groupName),
+					resource.TestCheckResourceAttrPair("data.incus_identity.identity1", "identifier", "incus_certificate.cert1", "fingerprint"),
+					resource.TestCheckResourceAttr("data.incus_identity.identity1", "groups.#", "1"),
+					resource.TestCheckResourceAttr("data.incus_identity.identity1", "groups.0", groupName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIdentity_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckAPIExtensions(t, "access_management")
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccIdentity_notFound(),
+				ExpectError: regexp.MustCompile(`Identity "nobody@example.com" not found`),
+			},
+		},
+	})
+}
+
+func testAccIdentity_tls(groupName string, certificateName string) string {
+	return fmt.Sprintf(`
+resource "incus_auth_group" "group1" {
+  name = "%[1]s"
+}
+
+resource "incus_certificate" "cert1" {
+  name        = "%[2]s"
+  certificate = "-----BEGIN CERTIFICATE-----\nMIIBwjCCAUigAwIBAgIUCGycHG038IvNWOBtciK4Bk7fB3wwCgYIKoZIzj0EAwMw\nGDEWMBQGA1UEAwwNbWV0cmljcy5sb2NhbDAeFw0yNDExMDUxNzU2MDdaFw0zNDEx\nMDMxNzU2MDdaMBgxFjAUBgNVBAMMDW1ldHJpY3MubG9jYWwwdjAQBgcqhkjOPQIB\nBgUrgQQAIgNiAASJeWxvoByh7+4A6k+SrrpQ/NGBRPvqBloV5fTmy9uPaRMZew9K\nIVg/8+7ciXK4193eLeVBQiILxj++a5lCvthmJcbpRkckyXuhQc4/JMuTW2h6jYWX\nTsTZfJEnvYU4IpqjUzBRMB0GA1UdDgQWBBQAqliKxB7id1A+4TQU0adTAB0+RTAf\nBgNVHSMEGDAWgBQAqliKxB7id1A+4TQU0adTAB0+RTAPBgNVHRMBAf8EBTADAQH/\nMAoGCCqGSM49BAMDA2gAMGUCMFYzGT/0ko01qFrD8QFkqhNPzuSA6yV8p6SSKUk2\nJ/35p8EoEmVb1LWldJ4KOxu8nAIxAOkoWTOfi0Nrb4MeKyu1R2zqD+CfgUlZjhLi\n4+1L464g/5a/nSIfDX+VyC+PNGBQFw==\n-----END CERTIFICATE-----\n"
+}
+
+resource "incus_identity" "identity1" {
+  authentication_method = "tls"
+  name                  = incus_certificate.cert1.fingerprint
+  groups                = [incus_auth_group.group1.name]
+}
+
+data "incus_identity" "identity1" {
+  authentication_method = "tls"
+  name                  = incus_identity.identity1.identifier
+}
+`, groupName, certificateName)
+}
+
+func testAccIdentity_notFound() string {
+	return `
+resource "incus_identity" "identity1" {
+  authentication_method = "oidc"
+  name                  = "nobody@example.com"
+}
+`
+}
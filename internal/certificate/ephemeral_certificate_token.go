@@ -0,0 +1,205 @@
+package certificate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// certificateTokenPrivateKey is the key of the private data holding the
+// operation of the ephemeral certificate token.
+const certificateTokenPrivateKey = "certificate_token"
+
+// certificateTokenPrivateData identifies the certificate token to revoke
+// once the ephemeral resource is closed.
+type certificateTokenPrivateData struct {
+	Remote      string `json:"remote"`
+	Name        string `json:"name"`
+	OperationID string `json:"operation_id"`
+}
+
+// CertificateTokenEphemeralResource represent Incus certificate add token
+// ephemeral resource. The token only exists for the duration of a Terraform
+// run, so that it is never stored in the state.
+type CertificateTokenEphemeralResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewCertificateTokenEphemeralResource returns a new certificate token
+// ephemeral resource.
+func NewCertificateTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &CertificateTokenEphemeralResource{}
+}
+
+func (r *CertificateTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_certificate_token", req.ProviderTypeName)
+}
+
+func (r *CertificateTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"projects": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					// Prevent empty values.
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"restricted": schema.BoolAttribute{
+				Optional: true,
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			// Computed.
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *CertificateTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *CertificateTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config CertificateTokenModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := config.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	projects, diags := toProjectList(ctx, config.Projects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+	certificate := api.CertificatesPost{
+		CertificatePut: api.CertificatePut{
+			Name:        name,
+			Type:        "client",
+			Restricted:  config.Restricted.ValueBool(),
+			Projects:    projects,
+			Description: config.Description.ValueString(),
+		},
+		Token: true,
+	}
+
+	op, err := server.CreateCertificateToken(certificate)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create certificate token %q", name), err.Error())
+		return
+	}
+
+	opAPI := op.Get()
+	addToken, err := opAPI.ToCertificateAddToken()
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve certificate token %q", name), err.Error())
+		return
+	}
+
+	privateData, err := json.Marshal(certificateTokenPrivateData{
+		Remote:      remote,
+		Name:        name,
+		OperationID: opAPI.ID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to encode certificate token %q", name), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, certificateTokenPrivateKey, privateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Token = types.StringValue(addToken.String())
+	config.ExpiresAt = common.ToTimestampType(addToken.ExpiresAt, types.StringNull())
+
+	diags = resp.Result.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Close revokes the token at the end of the run, if it has not been used.
+// A certificate that was added using the token is left untouched.
+func (r *CertificateTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, diags := req.Private.GetKey(ctx, certificateTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateData == nil {
+		return
+	}
+
+	var data certificateTokenPrivateData
+	err := json.Unmarshal(privateData, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode certificate token", err.Error())
+		return
+	}
+
+	server, err := r.provider.InstanceServer(data.Remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	err = server.DeleteOperation(data.OperationID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke certificate token %q", data.Name), err.Error())
+	}
+}
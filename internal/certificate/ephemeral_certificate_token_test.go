@@ -0,0 +1,47 @@
+package certificate_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccCertificateTokenEphemeral_basic(t *testing.T) {
+	name := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertificateTokenEphemeral_basic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.token", "data.name", name),
+					resource.TestCheckResourceAttrSet("echo.token", "data.token"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCertificateTokenEphemeral_basic(name string) string {
+	return fmt.Sprintf(`
+ephemeral "incus_certificate_token" "token" {
+  name = "%s"
+}
+
+provider "echo" {
+  data = ephemeral.incus_certificate_token.token
+}
+
+resource "echo" "token" {}
+`, name)
+}
@@ -0,0 +1,302 @@
+package certificate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type CertificateTokenModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Projects    types.Set    `tfsdk:"projects"`
+	Restricted  types.Bool   `tfsdk:"restricted"`
+	Remote      types.String `tfsdk:"remote"`
+
+	// Computed.
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// CertificateTokenResource represent Incus certificate add token resource.
+type CertificateTokenResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewCertificateTokenResource returns a new certificate token resource.
+func NewCertificateTokenResource() resource.Resource {
+	return &CertificateTokenResource{}
+}
+
+func (r *CertificateTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_certificate_token", req.ProviderTypeName)
+}
+
+func (r *CertificateTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"projects": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Set{
+					// Prevent empty values.
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"restricted": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CertificateTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *CertificateTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CertificateTokenModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	projects, diags := toProjectList(ctx, plan.Projects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := plan.Name.ValueString()
+	certificate := api.CertificatesPost{
+		CertificatePut: api.CertificatePut{
+			Name:        name,
+			Type:        "client",
+			Restricted:  plan.Restricted.ValueBool(),
+			Projects:    projects,
+			Description: plan.Description.ValueString(),
+		},
+		Token: true,
+	}
+
+	op, err := server.CreateCertificateToken(certificate)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create certificate token %q", name), err.Error())
+		return
+	}
+
+	opAPI := op.Get()
+	addToken, err := opAPI.ToCertificateAddToken()
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve certificate token %q", name), err.Error())
+		return
+	}
+
+	plan.Token = types.StringValue(addToken.String())
+	plan.ExpiresAt = common.ToTimestampType(addToken.ExpiresAt, types.StringNull())
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read removes the token from the state once it expires or is revoked, so
+// that a new token is issued. A token that has been used to add a client
+// certificate is kept.
+func (r *CertificateTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CertificateTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	name := state.Name.ValueString()
+	op, err := findCertificateTokenOperation(server, name)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve certificate token %q", name), err.Error())
+		return
+	}
+
+	if op != nil {
+		return
+	}
+
+	certificates, err := server.GetCertificates()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve certificates", err.Error())
+		return
+	}
+
+	for _, certificate := range certificates {
+		if certificate.Name == name {
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// Update is a no-op, as any change requires a new token.
+func (r *CertificateTokenResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+// Delete revokes the token if it has not been used yet. A certificate that
+// was added using the token is left untouched.
+func (r *CertificateTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CertificateTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	name := state.Name.ValueString()
+	op, err := findCertificateTokenOperation(server, name)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve certificate token %q", name), err.Error())
+		return
+	}
+
+	if op == nil {
+		return
+	}
+
+	err = server.DeleteOperation(op.ID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke certificate token %q", name), err.Error())
+	}
+}
+
+// findCertificateTokenOperation returns the pending certificate add token
+// operation for the client with the given name. Nil is returned if there is
+// no such token.
+func findCertificateTokenOperation(server incus.InstanceServer, name string) (*api.Operation, error) {
+	ops, err := server.GetOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops {
+		if op.Class != api.OperationClassToken || op.StatusCode != api.Running {
+			continue
+		}
+
+		addToken, err := op.ToCertificateAddToken()
+		if err != nil {
+			// Not a certificate add token.
+			continue
+		}
+
+		if addToken.ClientName == name {
+			return &op, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package certificate_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccCertificateToken_basic(t *testing.T) {
+	tokenName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertificateToken_basic(tokenName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "name", tokenName),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "description", ""),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "restricted", "false"),
+					resource.TestCheckResourceAttrSet("incus_certificate_token.token1", "token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCertificateToken_withProject(t *testing.T) {
+	tokenName := petname.Generate(2, "-")
+	projectName := petname.Generate(1, "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCertificateToken_withProject(tokenName, projectName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "name", tokenName),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "restricted", "true"),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "projects.#", "1"),
+					resource.TestCheckResourceAttr("incus_certificate_token.token1", "projects.0", projectName),
+					resource.TestCheckResourceAttrSet("incus_certificate_token.token1", "token"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCertificateToken_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_certificate_token" "token1" {
+  name = "%s"
+}
+`, name)
+}
+
+func testAccCertificateToken_withProject(name string, projectName string) string {
+	return fmt.Sprintf(`
+resource "incus_project" "project1" {
+  name = "%[2]s"
+}
+
+resource "incus_certificate_token" "token1" {
+  name       = "%[1]s"
+  restricted = true
+  projects   = [incus_project.project1.name]
+}
+`, name, projectName)
+}
@@ -0,0 +1,246 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type ClusterJoinTokenModel struct {
+	Name   types.String `tfsdk:"name"`
+	Remote types.String `tfsdk:"remote"`
+
+	// Computed.
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// ClusterJoinTokenResource represent Incus cluster join token resource.
+type ClusterJoinTokenResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewClusterJoinTokenResource returns a new cluster join token resource.
+func NewClusterJoinTokenResource() resource.Resource {
+	return &ClusterJoinTokenResource{}
+}
+
+func (r *ClusterJoinTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_cluster_join_token", req.ProviderTypeName)
+}
+
+func (r *ClusterJoinTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"token": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterJoinTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *ClusterJoinTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ClusterJoinTokenModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := plan.Name.ValueString()
+	memberReq := api.ClusterMembersPost{
+		ServerName: memberName,
+	}
+
+	op, err := server.CreateClusterMember(memberReq)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create join token for cluster member %q", memberName), err.Error())
+		return
+	}
+
+	opAPI := op.Get()
+	joinToken, err := opAPI.ToClusterJoinToken()
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve join token for cluster member %q", memberName), err.Error())
+		return
+	}
+
+	plan.Token = types.StringValue(joinToken.String())
+	plan.ExpiresAt = common.ToTimestampType(joinToken.ExpiresAt, types.StringNull())
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read removes the token from the state once it expires or is revoked, so
+// that a new token is issued. A token that has been used to join the cluster
+// is kept.
+func (r *ClusterJoinTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ClusterJoinTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := state.Name.ValueString()
+	op, err := findClusterJoinTokenOperation(server, memberName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve join token for cluster member %q", memberName), err.Error())
+		return
+	}
+
+	if op != nil {
+		return
+	}
+
+	_, _, err = server.GetClusterMember(memberName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve cluster member %q", memberName), err.Error())
+	}
+}
+
+// Update is a no-op, as any change requires a new token.
+func (r *ClusterJoinTokenResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+// Delete revokes the token if it has not been used yet.
+func (r *ClusterJoinTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ClusterJoinTokenModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := state.Name.ValueString()
+	op, err := findClusterJoinTokenOperation(server, memberName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve join token for cluster member %q", memberName), err.Error())
+		return
+	}
+
+	if op == nil {
+		return
+	}
+
+	err = server.DeleteOperation(op.ID)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to revoke join token for cluster member %q", memberName), err.Error())
+	}
+}
+
+// findClusterJoinTokenOperation returns the pending join token operation
+// for the cluster member with the given name. Nil is returned if there is
+// no such token.
+func findClusterJoinTokenOperation(server incus.InstanceServer, memberName string) (*api.Operation, error) {
+	ops, err := server.GetOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range ops {
+		if op.Class != api.OperationClassToken || op.StatusCode != api.Running {
+			continue
+		}
+
+		joinToken, err := op.ToClusterJoinToken()
+		if err != nil {
+			// Not a cluster join token.
+			continue
+		}
+
+		if joinToken.ServerName == memberName {
+			return &op, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,41 @@
+package cluster_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccClusterJoinToken_basic(t *testing.T) {
+	memberName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterJoinToken_basic(memberName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_join_token.token1", "name", memberName),
+					resource.TestCheckResourceAttrSet("incus_cluster_join_token.token1", "token"),
+					resource.TestCheckResourceAttrSet("incus_cluster_join_token.token1", "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccClusterJoinToken_basic(name string) string {
+	return fmt.Sprintf(`
+resource "incus_cluster_join_token" "token1" {
+  name = "%s"
+}
+`, name)
+}
@@ -0,0 +1,510 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// automaticMemberRoles are the cluster member roles assigned by Incus
+// itself. They cannot be managed by the user.
+var automaticMemberRoles = []string{"database", "database-leader", "database-standby"}
+
+type ClusterMemberModel struct {
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Config        types.Map    `tfsdk:"config"`
+	FailureDomain types.String `tfsdk:"failure_domain"`
+	Roles         types.Set    `tfsdk:"roles"`
+	Groups        types.Set    `tfsdk:"groups"`
+	Evacuated     types.Bool   `tfsdk:"evacuated"`
+	Action        types.String `tfsdk:"action"`
+	Force         types.Bool   `tfsdk:"force"`
+	Remote        types.String `tfsdk:"remote"`
+
+	// Computed.
+	URL          types.String `tfsdk:"url"`
+	Architecture types.String `tfsdk:"architecture"`
+	Database     types.Bool   `tfsdk:"database"`
+	Status       types.String `tfsdk:"status"`
+}
+
+// ClusterMemberResource represent Incus cluster member resource.
+type ClusterMemberResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewClusterMemberResource returns a new cluster member resource.
+func NewClusterMemberResource() resource.Resource {
+	return &ClusterMemberResource{}
+}
+
+func (r *ClusterMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_cluster_member", req.ProviderTypeName)
+}
+
+func (r *ClusterMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			// The description, config and failure domain of an adopted
+			// member are kept unless set in the configuration.
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"config": schema.MapAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"failure_domain": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			// Roles assigned automatically by Incus are not included.
+			"roles": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+						stringvalidator.NoneOf(automaticMemberRoles...),
+					),
+				},
+			},
+
+			"groups": schema.SetAttribute{
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"evacuated": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"action": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("stop", "migrate", "live-migrate"),
+				},
+			},
+
+			"force": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"architecture": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"database": schema.BoolAttribute{
+				Computed: true,
+			},
+
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *ClusterMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// Create starts managing an existing cluster member. Servers join the
+// cluster on their own, for example using a cluster join token, and
+// cannot be added from here.
+func (r *ClusterMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ClusterMemberModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := plan.Name.ValueString()
+	member, etag, err := server.GetClusterMember(memberName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Cluster member %q not found", memberName),
+				"Servers must join the cluster before they can be managed as cluster members.",
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve cluster member %q", memberName), err.Error())
+		return
+	}
+
+	diags = r.updateMember(ctx, server, member, etag, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ClusterMemberModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *ClusterMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ClusterMemberModel
+	var state ClusterMemberModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Rename cluster member.
+	memberName := state.Name.ValueString()
+	newMemberName := plan.Name.ValueString()
+	if memberName != newMemberName {
+		err := server.RenameClusterMember(memberName, api.ClusterMemberPost{ServerName: newMemberName})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to rename cluster member %q", memberName), err.Error())
+			return
+		}
+
+		memberName = newMemberName
+	}
+
+	member, etag, err := server.GetClusterMember(memberName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve cluster member %q", memberName), err.Error())
+		return
+	}
+
+	diags = r.updateMember(ctx, server, member, etag, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the member from the cluster.
+func (r *ClusterMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ClusterMemberModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	server, err := r.provider.InstanceServer(remote, "", "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	memberName := state.Name.ValueString()
+	err = server.DeleteClusterMember(memberName, state.Force.ValueBool())
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove cluster member %q", memberName), err.Error())
+	}
+}
+
+func (r *ClusterMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "cluster_member",
+		RequiredFields: []string{"name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// updateMember applies the configuration from the plan to the given cluster
+// member, and evacuates or restores the member if its evacuation state
+// differs from the planned one. Attributes that are unknown in the plan,
+// because they are not set in the configuration, keep their current value.
+func (r *ClusterMemberResource) updateMember(ctx context.Context, server incus.InstanceServer, member *api.ClusterMember, etag string, plan ClusterMemberModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	memberName := member.ServerName
+
+	memberPut := member.Writable()
+
+	if !plan.Description.IsUnknown() {
+		memberPut.Description = plan.Description.ValueString()
+	}
+
+	if !plan.Config.IsUnknown() {
+		config, diags := common.ToConfigMap(ctx, plan.Config)
+		respDiags.Append(diags...)
+
+		memberPut.Config = config
+	}
+
+	if !plan.FailureDomain.IsUnknown() {
+		memberPut.FailureDomain = plan.FailureDomain.ValueString()
+	}
+
+	if !plan.Roles.IsUnknown() {
+		roles, diags := ToMemberSet(ctx, plan.Roles)
+		respDiags.Append(diags...)
+
+		// Keep the roles that are managed by Incus.
+		for _, role := range member.Roles {
+			if slices.Contains(automaticMemberRoles, role) {
+				roles = append(roles, role)
+			}
+		}
+
+		memberPut.Roles = roles
+	}
+
+	if !plan.Groups.IsUnknown() {
+		groups, diags := ToMemberSet(ctx, plan.Groups)
+		respDiags.Append(diags...)
+
+		memberPut.Groups = groups
+	}
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	err := server.UpdateClusterMember(memberName, memberPut, etag)
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to update cluster member %q", memberName), err.Error())
+		return respDiags
+	}
+
+	if plan.Evacuated.IsUnknown() || plan.Evacuated.IsNull() {
+		return respDiags
+	}
+
+	evacuated := isMemberEvacuated(*member)
+	if plan.Evacuated.ValueBool() == evacuated {
+		return respDiags
+	}
+
+	stateReq := api.ClusterMemberStatePost{
+		Action: "restore",
+	}
+
+	if plan.Evacuated.ValueBool() {
+		stateReq.Action = "evacuate"
+		stateReq.Mode = plan.Action.ValueString()
+	}
+
+	op, err := server.UpdateClusterMemberState(memberName, stateReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		respDiags.AddError(fmt.Sprintf("Failed to %s cluster member %q", stateReq.Action, memberName), err.Error())
+		return respDiags
+	}
+
+	return respDiags
+}
+
+// SyncState fetches the server's current state for a cluster member and
+// updates the provided model. It then applies this updated model as the
+// new state in Terraform.
+func (r *ClusterMemberResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m ClusterMemberModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	memberName := m.Name.ValueString()
+	member, _, err := server.GetClusterMember(memberName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve cluster member %q", memberName), err.Error())
+		return respDiags
+	}
+
+	roles := make([]string, 0, len(member.Roles))
+	for _, role := range member.Roles {
+		if !slices.Contains(automaticMemberRoles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	config, diags := common.ToConfigMapType(ctx, common.ToNullableConfig(member.Config), m.Config)
+	respDiags.Append(diags...)
+
+	rolesSet, diags := types.SetValueFrom(ctx, types.StringType, roles)
+	respDiags.Append(diags...)
+
+	groupsSet, diags := types.SetValueFrom(ctx, types.StringType, member.Groups)
+	respDiags.Append(diags...)
+
+	if respDiags.HasError() {
+		return respDiags
+	}
+
+	m.Name = types.StringValue(member.ServerName)
+	m.Description = types.StringValue(member.Description)
+	m.Config = config
+	m.FailureDomain = types.StringValue(member.FailureDomain)
+	m.Roles = rolesSet
+	m.Groups = groupsSet
+	m.Evacuated = types.BoolValue(isMemberEvacuated(*member))
+	m.URL = types.StringValue(member.URL)
+	m.Architecture = types.StringValue(member.Architecture)
+	m.Database = types.BoolValue(member.Database)
+	m.Status = types.StringValue(member.Status)
+
+	// Imported cluster members are not removed forcefully.
+	if m.Force.IsNull() {
+		m.Force = types.BoolValue(false)
+	}
+
+	return tfState.Set(ctx, &m)
+}
+
+// isMemberEvacuated returns true if the cluster member is evacuated.
+func isMemberEvacuated(member api.ClusterMember) bool {
+	return member.Status == "Evacuated"
+}
@@ -0,0 +1,287 @@
+package cluster_test
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+// Cluster members are removed from the cluster when the resource is
+// destroyed. Tests which adopt an existing member therefore restore its
+// configuration and stop managing it using a "removed" block.
+func TestAccClusterMember_notFound(t *testing.T) {
+	memberName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccClusterMember_basic(memberName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`Cluster member "%s" not found`, memberName)),
+			},
+		},
+	})
+}
+
+func TestAccClusterMember_adopt(t *testing.T) {
+	var member api.ClusterMember
+	vars := config.Variables{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+			member = testAccClusterMember_variables(t, vars)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testAccClusterMember_adopt(),
+				ConfigVariables: vars,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "name", &member.ServerName),
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "description", &member.Description),
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "failure_domain", &member.FailureDomain),
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "url", &member.URL),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "evacuated", "false"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "status", "Online"),
+				),
+			},
+			{
+				Config: testAccClusterMember_removed(""),
+			},
+		},
+	})
+}
+
+func TestAccClusterMember_update(t *testing.T) {
+	var member api.ClusterMember
+	groupName := petname.Generate(2, "-")
+	vars := config.Variables{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+			member = testAccClusterMember_variables(t, vars)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testAccClusterMember_update(groupName),
+				ConfigVariables: vars,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "name", &member.ServerName),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "description", "Managed by Terraform"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "failure_domain", "acctest"),
+					resource.TestCheckTypeSetElemAttr("incus_cluster_member.member1", "roles.*", "event-hub"),
+					resource.TestCheckTypeSetElemAttr("incus_cluster_member.member1", "groups.*", groupName),
+				),
+			},
+			{
+				// Restore the original configuration of the member.
+				Config:          testAccClusterMember_restore(groupName),
+				ConfigVariables: vars,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "description", &member.Description),
+					resource.TestCheckResourceAttrPtr("incus_cluster_member.member1", "failure_domain", &member.FailureDomain),
+				),
+			},
+			{
+				Config: testAccClusterMember_removed(testAccClusterMember_group(groupName)),
+			},
+		},
+	})
+}
+
+func TestAccClusterMember_evacuate(t *testing.T) {
+	vars := config.Variables{}
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+			testAccClusterMember_variables(t, vars)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testAccClusterMember_evacuated(true),
+				ConfigVariables: vars,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "evacuated", "true"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "status", "Evacuated"),
+				),
+			},
+			{
+				Config:          testAccClusterMember_evacuated(false),
+				ConfigVariables: vars,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "evacuated", "false"),
+					resource.TestCheckResourceAttr("incus_cluster_member.member1", "status", "Online"),
+				),
+			},
+			{
+				Config: testAccClusterMember_removed(""),
+			},
+		},
+	})
+}
+
+// testAccClusterMember_variables sets the name and the original configuration
+// of the cluster member used by the tests as Terraform variables.
+func testAccClusterMember_variables(t *testing.T, vars config.Variables) api.ClusterMember {
+	t.Helper()
+
+	member := acctest.GetClusterMember(t)
+
+	roles := make([]config.Variable, 0, len(member.Roles))
+	for _, role := range member.Roles {
+		// Roles assigned by Incus cannot be set in the configuration.
+		if strings.HasPrefix(role, "database") {
+			continue
+		}
+
+		roles = append(roles, config.StringVariable(role))
+	}
+
+	groups := make([]config.Variable, 0, len(member.Groups))
+	for _, group := range member.Groups {
+		groups = append(groups, config.StringVariable(group))
+	}
+
+	vars["name"] = config.StringVariable(member.ServerName)
+	vars["description"] = config.StringVariable(member.Description)
+	vars["failure_domain"] = config.StringVariable(member.FailureDomain)
+	vars["roles"] = config.SetVariable(roles...)
+	vars["groups"] = config.SetVariable(groups...)
+
+	return member
+}
+
+const testAccClusterMember_vars = `
+variable "name" {
+  type = string
+}
+
+variable "description" {
+  type = string
+}
+
+variable "failure_domain" {
+  type = string
+}
+
+variable "roles" {
+  type = set(string)
+}
+
+variable "groups" {
+  type = set(string)
+}
+`
+
+func testAccClusterMember_basic(memberName string) string {
+	return fmt.Sprintf(`
+resource "incus_cluster_member" "member1" {
+  name           = "%s"
+  description    = "Managed by Terraform"
+  failure_domain = "rack1"
+}
+`, memberName)
+}
+
+func testAccClusterMember_adopt() string {
+	return fmt.Sprintf(`
+%s
+
+resource "incus_cluster_member" "member1" {
+  name = var.name
+}
+`, testAccClusterMember_vars)
+}
+
+func testAccClusterMember_group(groupName string) string {
+	return fmt.Sprintf(`
+resource "incus_cluster_group" "group1" {
+  name = "%s"
+}
+`, groupName)
+}
+
+func testAccClusterMember_update(groupName string) string {
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "incus_cluster_member" "member1" {
+  name           = var.name
+  description    = "Managed by Terraform"
+  failure_domain = "acctest"
+  roles          = setunion(var.roles, ["event-hub"])
+  groups         = setunion(var.groups, [incus_cluster_group.group1.name])
+}
+`, testAccClusterMember_vars, testAccClusterMember_group(groupName))
+}
+
+func testAccClusterMember_restore(groupName string) string {
+	return fmt.Sprintf(`
+%s
+%s
+
+resource "incus_cluster_member" "member1" {
+  name           = var.name
+  description    = var.description
+  failure_domain = var.failure_domain
+  roles          = var.roles
+  groups         = var.groups
+}
+`, testAccClusterMember_vars, testAccClusterMember_group(groupName))
+}
+
+func testAccClusterMember_evacuated(evacuated bool) string {
+	return fmt.Sprintf(`
+%s
+
+resource "incus_cluster_member" "member1" {
+  name      = var.name
+  evacuated = %t
+  action    = "stop"
+}
+`, testAccClusterMember_vars, evacuated)
+}
+
+func testAccClusterMember_removed(extra string) string {
+	return fmt.Sprintf(`
+%s
+
+removed {
+  from = incus_cluster_member.member1
+  lifecycle {
+    destroy = false
+  }
+}
+`, extra)
+}
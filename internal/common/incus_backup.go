@@ -0,0 +1,165 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/mitchellh/go-homedir"
+)
+
+// BackupFileDownloader retrieves a backup file using the given request.
+type BackupFileDownloader func(req *incus.BackupFileRequest) (*incus.BackupFileResponse, error)
+
+// BackupFileDownload downloads a backup into the file at the given path
+// and returns its size and SHA-256 checksum. The backup is first written
+// into a temporary file next to the target, so an interrupted download
+// never leaves a partial backup at the target path.
+func BackupFileDownload(outputPath string, download BackupFileDownloader) (int64, string, error) {
+	outputPath, err := homedir.Expand(outputPath)
+	if err != nil {
+		return 0, "", fmt.Errorf("Unable to determine output path %q: %w", outputPath, err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(outputPath), "."+filepath.Base(outputPath)+".*")
+	if err != nil {
+		return 0, "", fmt.Errorf("Unable to create backup file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+	defer func() { _ = tmpFile.Close() }()
+
+	_, err = download(&incus.BackupFileRequest{BackupFile: tmpFile})
+	if err != nil {
+		return 0, "", fmt.Errorf("Unable to download backup file: %w", err)
+	}
+
+	_, err = tmpFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return 0, "", err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, tmpFile)
+	if err != nil {
+		return 0, "", fmt.Errorf("Unable to compute backup file checksum: %w", err)
+	}
+
+	err = tmpFile.Close()
+	if err != nil {
+		return 0, "", err
+	}
+
+	err = os.Rename(tmpFile.Name(), outputPath)
+	if err != nil {
+		return 0, "", fmt.Errorf("Unable to write backup file %q: %w", outputPath, err)
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// DownloadBackupFile downloads the backup into the output path, if one is
+// provided, and returns the size and checksum of the downloaded file as
+// stored in the state. Both are null if no output path is provided.
+func DownloadBackupFile(outputPath types.String, download BackupFileDownloader) (types.Int64, types.String, error) {
+	if outputPath.IsNull() || outputPath.IsUnknown() {
+		return types.Int64Null(), types.StringNull(), nil
+	}
+
+	size, checksum, err := BackupFileDownload(outputPath.ValueString(), download)
+	if err != nil {
+		return types.Int64Null(), types.StringNull(), err
+	}
+
+	return types.Int64Value(size), types.StringValue(checksum), nil
+}
+
+// BackupFileChecksum returns the SHA-256 checksum of the backup file at the
+// given path. An empty checksum is returned if the file does not exist.
+func BackupFileChecksum(outputPath string) (string, error) {
+	outputPath, err := homedir.Expand(outputPath)
+	if err != nil {
+		return "", fmt.Errorf("Unable to determine output path %q: %w", outputPath, err)
+	}
+
+	f, err := os.Open(outputPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("Unable to open backup file: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		return "", fmt.Errorf("Unable to compute backup file checksum: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// SyncBackupFile verifies that the file in the output path still matches the
+// downloaded backup. If the file was removed or modified, the size and
+// checksum are reset to null, which marks the backup for replacement (see
+// PlanBackupFile), so that it is downloaded again.
+func SyncBackupFile(outputPath types.String, size *types.Int64, checksum *types.String) error {
+	if outputPath.IsNull() || checksum.IsNull() {
+		return nil
+	}
+
+	actual, err := BackupFileChecksum(outputPath.ValueString())
+	if err != nil {
+		return err
+	}
+
+	if actual != checksum.ValueString() {
+		*size = types.Int64Null()
+		*checksum = types.StringNull()
+	}
+
+	return nil
+}
+
+// PlanBackupFile plans the replacement of a backup whose downloaded file is
+// missing or modified, that is, the backup has an output path but its
+// checksum was reset by SyncBackupFile.
+func PlanBackupFile(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var outputPath types.String
+	var stateOutputPath types.String
+	var stateChecksum types.String
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("output_path"), &outputPath)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("output_path"), &stateOutputPath)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("sha256"), &stateChecksum)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A changed output path already results in a replacement.
+	if outputPath.IsNull() || !outputPath.Equal(stateOutputPath) || !stateChecksum.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("size"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("sha256"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("sha256"))
+}
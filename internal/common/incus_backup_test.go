@@ -0,0 +1,73 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncBackupFile(t *testing.T) {
+	// SHA-256 checksum of "hello".
+	checksum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unchanged.tar.gz"), []byte("hello"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "modified.tar.gz"), []byte("world"), 0o600))
+
+	tests := []struct {
+		Name         string
+		OutputPath   types.String
+		Checksum     types.String
+		WantChecksum types.String
+		WantSize     types.Int64
+	}{
+		{
+			Name:         "No output path",
+			OutputPath:   types.StringNull(),
+			Checksum:     types.StringNull(),
+			WantChecksum: types.StringNull(),
+			WantSize:     types.Int64Null(),
+		},
+		{
+			Name:         "Unchanged file",
+			OutputPath:   types.StringValue(filepath.Join(dir, "unchanged.tar.gz")),
+			Checksum:     types.StringValue(checksum),
+			WantChecksum: types.StringValue(checksum),
+			WantSize:     types.Int64Value(5),
+		},
+		{
+			Name:         "Modified file",
+			OutputPath:   types.StringValue(filepath.Join(dir, "modified.tar.gz")),
+			Checksum:     types.StringValue(checksum),
+			WantChecksum: types.StringNull(),
+			WantSize:     types.Int64Null(),
+		},
+		{
+			Name:         "Missing file",
+			OutputPath:   types.StringValue(filepath.Join(dir, "missing.tar.gz")),
+			Checksum:     types.StringValue(checksum),
+			WantChecksum: types.StringNull(),
+			WantSize:     types.Int64Null(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			size := types.Int64Null()
+			if !test.Checksum.IsNull() {
+				size = types.Int64Value(5)
+			}
+
+			checksum := test.Checksum
+
+			err := SyncBackupFile(test.OutputPath, &size, &checksum)
+			require.NoError(t, err)
+			assert.Equal(t, test.WantChecksum, checksum)
+			assert.Equal(t, test.WantSize, size)
+		})
+	}
+}
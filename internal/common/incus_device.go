@@ -56,6 +56,11 @@ func ToDeviceSetTypePreservingNulls(ctx context.Context, devices map[string]map[
 	return toDeviceSetType(ctx, devices, modelDevices, true)
 }
 
+// DeviceSetType returns the type of the devices returned by ToDeviceSetType.
+func DeviceSetType() types.SetType {
+	return types.SetType{ElemType: types.ObjectType{AttrTypes: deviceType()}}
+}
+
 func deviceType() map[string]attr.Type {
 	return map[string]attr.Type{
 		"name":       types.StringType,
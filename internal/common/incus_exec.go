@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -20,6 +21,17 @@ type InstanceExecModel struct {
 	GroupID     types.Int64  `tfsdk:"gid"`
 	Timeout     types.String `tfsdk:"timeout"`
 	Trigger     types.String `tfsdk:"trigger"`
+	Phase       types.String `tfsdk:"phase"`
+	OnFailure   types.String `tfsdk:"on_failure"`
+
+	CaptureOutput types.Bool  `tfsdk:"capture_output"`
+	MaxOutputSize types.Int64 `tfsdk:"max_output_size"`
+
+	// Computed.
+	Stdout    types.String `tfsdk:"stdout"`
+	Stderr    types.String `tfsdk:"stderr"`
+	ExitCode  types.Int64  `tfsdk:"exit_code"`
+	LastRunAt types.String `tfsdk:"last_run_at"`
 }
 
 type InstanceExecConfig struct {
@@ -33,6 +45,45 @@ type InstanceExecConfig struct {
 	Timeout     time.Duration
 	HasTimeout  bool
 	Trigger     string
+	Phase       string
+
+	// Neither the failure policy nor output capturing affect whether a
+	// command is run again.
+	OnFailure     string
+	CaptureOutput bool
+	MaxOutputSize int64
+}
+
+// Lifecycle phases in which exec commands can run. Commands without a phase
+// run after create and update depending on their trigger.
+const (
+	ExecPhaseOnCreate      = "on_create"
+	ExecPhaseOnStart       = "on_start"
+	ExecPhaseBeforeStop    = "before_stop"
+	ExecPhaseBeforeDestroy = "before_destroy"
+	ExecPhaseAfterUpdate   = "after_update"
+)
+
+// Failure policies of exec commands.
+const (
+	ExecOnFailureFail     = "fail"
+	ExecOnFailureContinue = "continue"
+)
+
+// DefaultExecMaxOutputSize is the default maximum number of bytes of each of
+// stdout and stderr, which are kept when the output of a command is captured.
+const DefaultExecMaxOutputSize = 64 * 1024
+
+// InstanceExecResult is the result of a command executed within an instance.
+type InstanceExecResult struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+
+	// Truncated is true if stdout or stderr exceeded the maximum output size.
+	Truncated bool
+
+	RunAt time.Time
 }
 
 func ToExecMap(ctx context.Context, execMap types.Map) (map[string]InstanceExecModel, diag.Diagnostics) {
@@ -52,8 +103,10 @@ func ToExecMap(ctx context.Context, execMap types.Map) (map[string]InstanceExecM
 func ToExecConfig(ctx context.Context, exec InstanceExecModel) (InstanceExecConfig, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	execConfig := InstanceExecConfig{
-		Environment: map[string]string{},
-		Trigger:     "on_change",
+		Environment:   map[string]string{},
+		Trigger:       "on_change",
+		OnFailure:     ExecOnFailureFail,
+		MaxOutputSize: DefaultExecMaxOutputSize,
 	}
 
 	if !exec.Command.IsNull() && !exec.Command.IsUnknown() {
@@ -105,6 +158,25 @@ func ToExecConfig(ctx context.Context, exec InstanceExecModel) (InstanceExecConf
 		}
 	}
 
+	if !exec.Phase.IsNull() && !exec.Phase.IsUnknown() {
+		execConfig.Phase = exec.Phase.ValueString()
+	}
+
+	if !exec.OnFailure.IsNull() && !exec.OnFailure.IsUnknown() {
+		onFailure := exec.OnFailure.ValueString()
+		if onFailure != "" {
+			execConfig.OnFailure = onFailure
+		}
+	}
+
+	if !exec.CaptureOutput.IsNull() && !exec.CaptureOutput.IsUnknown() {
+		execConfig.CaptureOutput = exec.CaptureOutput.ValueBool()
+	}
+
+	if !exec.MaxOutputSize.IsNull() && !exec.MaxOutputSize.IsUnknown() {
+		execConfig.MaxOutputSize = exec.MaxOutputSize.ValueInt64()
+	}
+
 	return execConfig, diags
 }
 
@@ -129,6 +201,10 @@ func ExecConfigEqual(a InstanceExecConfig, b InstanceExecConfig) bool {
 		return false
 	}
 
+	if a.Phase != b.Phase {
+		return false
+	}
+
 	if len(a.Command) != len(b.Command) {
 		return false
 	}
@@ -152,7 +228,14 @@ func ExecConfigEqual(a InstanceExecConfig, b InstanceExecConfig) bool {
 	return true
 }
 
-func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceName string, execConfig InstanceExecConfig) (string, string, error) {
+// RunInstanceExec runs the command within the instance. An error is returned
+// if the command fails or returns a non-zero exit code, in which case the
+// result still contains the output produced by the command.
+func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceName string, execConfig InstanceExecConfig) (InstanceExecResult, error) {
+	result := InstanceExecResult{
+		RunAt: time.Now().UTC(),
+	}
+
 	execReq := api.InstanceExecPost{
 		Command:     execConfig.Command,
 		WaitForWS:   true,
@@ -175,8 +258,20 @@ func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceN
 		execReq.Group = uint32(execConfig.GroupID)
 	}
 
-	stdout := &bytes.Buffer{}
-	stderr := &bytes.Buffer{}
+	// Captured output is limited, since it is stored in the state.
+	var limit int64
+	if execConfig.CaptureOutput {
+		limit = execConfig.MaxOutputSize
+	}
+
+	stdout := &limitedBuffer{limit: limit}
+	stderr := &limitedBuffer{limit: limit}
+
+	setOutput := func() {
+		result.Stdout = stdout.String()
+		result.Stderr = stderr.String()
+		result.Truncated = stdout.truncated || stderr.truncated
+	}
 
 	execArgs := incus.InstanceExecArgs{
 		Stdout:   stdout,
@@ -186,7 +281,8 @@ func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceN
 
 	op, err := server.ExecInstance(instanceName, execReq, &execArgs)
 	if err != nil {
-		return stdout.String(), stderr.String(), err
+		setOutput()
+		return result, err
 	}
 
 	waitCtx := ctx
@@ -198,29 +294,122 @@ func RunInstanceExec(ctx context.Context, server incus.InstanceServer, instanceN
 
 	err = op.WaitContext(waitCtx)
 	opAPI := op.Get()
-	exitStatus := 0
 	if opAPI.Metadata != nil {
 		exitStatusRaw, ok := opAPI.Metadata["return"].(float64)
 		if ok {
-			exitStatus = int(exitStatusRaw)
+			result.ExitCode = int(exitStatusRaw)
 		}
 	}
 
 	if err != nil {
-		return stdout.String(), stderr.String(), err
+		setOutput()
+		return result, err
 	}
 
 	if execArgs.DataDone != nil {
 		select {
 		case <-execArgs.DataDone:
 		case <-waitCtx.Done():
-			return stdout.String(), stderr.String(), waitCtx.Err()
+			setOutput()
+			return result, waitCtx.Err()
 		}
 	}
 
-	if exitStatus != 0 {
-		return stdout.String(), stderr.String(), fmt.Errorf("exec returned non-zero status %d", exitStatus)
+	setOutput()
+
+	if result.ExitCode != 0 {
+		return result, fmt.Errorf("exec returned non-zero status %d", result.ExitCode)
+	}
+
+	return result, nil
+}
+
+// limitedBuffer is a buffer, which keeps at most limit bytes and discards
+// the rest. A limit of zero means no limit.
+type limitedBuffer struct {
+	bytes.Buffer
+
+	limit     int64
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit <= 0 {
+		return b.Buffer.Write(p)
+	}
+
+	remaining := b.limit - int64(b.Len())
+	if int64(len(p)) > remaining {
+		b.truncated = true
+		_, _ = b.Buffer.Write(p[:max(remaining, 0)])
+		return len(p), nil
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// SetExecResults stores the results of the executed commands in the computed
+// attributes of the exec entries. Output is only stored if it is captured.
+// Unknown attributes of entries which were not run are taken from the prior
+// entries or set to null.
+func SetExecResults(ctx context.Context, execMap types.Map, priorExecs map[string]InstanceExecModel, results map[string]InstanceExecResult) (types.Map, diag.Diagnostics) {
+	if execMap.IsNull() || execMap.IsUnknown() {
+		return execMap, nil
+	}
+
+	execs, diags := ToExecMap(ctx, execMap)
+	if diags.HasError() {
+		return execMap, diags
+	}
+
+	for name, exec := range execs {
+		result, ok := results[name]
+		if ok {
+			exec.Stdout = types.StringNull()
+			exec.Stderr = types.StringNull()
+			if exec.CaptureOutput.ValueBool() {
+				exec.Stdout = types.StringValue(strings.ToValidUTF8(result.Stdout, "\uFFFD"))
+				exec.Stderr = types.StringValue(strings.ToValidUTF8(result.Stderr, "\uFFFD"))
+			}
+
+			exec.ExitCode = types.Int64Value(int64(result.ExitCode))
+			exec.LastRunAt = types.StringValue(result.RunAt.Format(time.RFC3339))
+		} else {
+			prior, ok := priorExecs[name]
+			if !ok {
+				prior = InstanceExecModel{
+					Stdout:    types.StringNull(),
+					Stderr:    types.StringNull(),
+					ExitCode:  types.Int64Null(),
+					LastRunAt: types.StringNull(),
+				}
+			}
+
+			if exec.Stdout.IsUnknown() {
+				exec.Stdout = prior.Stdout
+			}
+
+			if exec.Stderr.IsUnknown() {
+				exec.Stderr = prior.Stderr
+			}
+
+			if exec.ExitCode.IsUnknown() {
+				exec.ExitCode = prior.ExitCode
+			}
+
+			if exec.LastRunAt.IsUnknown() {
+				exec.LastRunAt = prior.LastRunAt
+			}
+
+			// Output is only stored if it is captured.
+			if !exec.CaptureOutput.ValueBool() {
+				exec.Stdout = types.StringNull()
+				exec.Stderr = types.StringNull()
+			}
+		}
+
+		execs[name] = exec
 	}
 
-	return stdout.String(), stderr.String(), nil
+	return types.MapValueFrom(ctx, execMap.ElementType(ctx), execs)
 }
@@ -0,0 +1,155 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 5}
+
+	n, err := buf.Write([]byte("hel"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.False(t, buf.truncated)
+
+	n, err = buf.Write([]byte("lo world"))
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.True(t, buf.truncated)
+	assert.Equal(t, "hello", buf.String())
+
+	unlimited := &limitedBuffer{}
+	_, err = unlimited.Write([]byte("hello world"))
+	require.NoError(t, err)
+	assert.False(t, unlimited.truncated)
+	assert.Equal(t, "hello world", unlimited.String())
+}
+
+func TestSetExecResults(t *testing.T) {
+	ctx := context.Background()
+
+	execType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"command":         types.ListType{ElemType: types.StringType},
+		"environment":     types.MapType{ElemType: types.StringType},
+		"working_dir":     types.StringType,
+		"uid":             types.Int64Type,
+		"gid":             types.Int64Type,
+		"timeout":         types.StringType,
+		"trigger":         types.StringType,
+		"phase":           types.StringType,
+		"on_failure":      types.StringType,
+		"capture_output":  types.BoolType,
+		"max_output_size": types.Int64Type,
+		"stdout":          types.StringType,
+		"stderr":          types.StringType,
+		"exit_code":       types.Int64Type,
+		"last_run_at":     types.StringType,
+	}}
+
+	exec := func(captureOutput bool) InstanceExecModel {
+		return InstanceExecModel{
+			Command:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("true")}),
+			Environment:   types.MapNull(types.StringType),
+			WorkingDir:    types.StringNull(),
+			UserID:        types.Int64Null(),
+			GroupID:       types.Int64Null(),
+			Timeout:       types.StringNull(),
+			Trigger:       types.StringNull(),
+			Phase:         types.StringNull(),
+			OnFailure:     types.StringNull(),
+			CaptureOutput: types.BoolValue(captureOutput),
+			MaxOutputSize: types.Int64Null(),
+			Stdout:        types.StringUnknown(),
+			Stderr:        types.StringUnknown(),
+			ExitCode:      types.Int64Unknown(),
+			LastRunAt:     types.StringUnknown(),
+		}
+	}
+
+	execMap, diags := types.MapValueFrom(ctx, execType, map[string]InstanceExecModel{
+		"captured":  exec(true),
+		"discarded": exec(false),
+		"skipped":   exec(true),
+		"new":       exec(true),
+	})
+	require.False(t, diags.HasError())
+
+	runAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	results := map[string]InstanceExecResult{
+		"captured":  {Stdout: "out\xff", Stderr: "err", ExitCode: 0, RunAt: runAt},
+		"discarded": {Stdout: "out", Stderr: "err", ExitCode: 0, RunAt: runAt},
+	}
+
+	prior := exec(true)
+	prior.Stdout = types.StringValue("previous")
+	prior.Stderr = types.StringValue("")
+	prior.ExitCode = types.Int64Value(0)
+	prior.LastRunAt = types.StringValue("2025-01-01T00:00:00Z")
+
+	got, diags := SetExecResults(ctx, execMap, map[string]InstanceExecModel{"skipped": prior}, results)
+	require.False(t, diags.HasError())
+
+	execs, diags := ToExecMap(ctx, got)
+	require.False(t, diags.HasError())
+
+	assert.Equal(t, types.StringValue("out�"), execs["captured"].Stdout)
+	assert.Equal(t, types.StringValue("err"), execs["captured"].Stderr)
+	assert.Equal(t, types.Int64Value(0), execs["captured"].ExitCode)
+	assert.Equal(t, types.StringValue("2026-01-02T03:04:05Z"), execs["captured"].LastRunAt)
+
+	assert.True(t, execs["discarded"].Stdout.IsNull())
+	assert.True(t, execs["discarded"].Stderr.IsNull())
+	assert.Equal(t, types.StringValue("2026-01-02T03:04:05Z"), execs["discarded"].LastRunAt)
+
+	assert.Equal(t, types.StringValue("previous"), execs["skipped"].Stdout)
+	assert.Equal(t, types.StringValue("2025-01-01T00:00:00Z"), execs["skipped"].LastRunAt)
+
+	assert.True(t, execs["new"].Stdout.IsNull())
+	assert.True(t, execs["new"].ExitCode.IsNull())
+	assert.True(t, execs["new"].LastRunAt.IsNull())
+}
+
+func TestToExecConfig_phase(t *testing.T) {
+	ctx := context.Background()
+
+	exec := InstanceExecModel{
+		Command:       types.ListValueMust(types.StringType, []attr.Value{types.StringValue("true")}),
+		Environment:   types.MapNull(types.StringType),
+		WorkingDir:    types.StringNull(),
+		UserID:        types.Int64Null(),
+		GroupID:       types.Int64Null(),
+		Timeout:       types.StringNull(),
+		Trigger:       types.StringNull(),
+		Phase:         types.StringValue(ExecPhaseBeforeStop),
+		OnFailure:     types.StringNull(),
+		CaptureOutput: types.BoolNull(),
+		MaxOutputSize: types.Int64Null(),
+	}
+
+	execConfig, diags := ToExecConfig(ctx, exec)
+	require.False(t, diags.HasError())
+	assert.Equal(t, ExecPhaseBeforeStop, execConfig.Phase)
+	assert.Equal(t, ExecOnFailureFail, execConfig.OnFailure)
+	assert.Equal(t, int64(DefaultExecMaxOutputSize), execConfig.MaxOutputSize)
+
+	// Changing the failure policy does not run the command again, changing
+	// the phase does.
+	changed := exec
+	changed.OnFailure = types.StringValue(ExecOnFailureContinue)
+	changedConfig, diags := ToExecConfig(ctx, changed)
+	require.False(t, diags.HasError())
+	assert.Equal(t, ExecOnFailureContinue, changedConfig.OnFailure)
+	assert.True(t, ExecConfigEqual(execConfig, changedConfig))
+
+	changed.Phase = types.StringValue(ExecPhaseOnStart)
+	changedConfig, diags = ToExecConfig(ctx, changed)
+	require.False(t, diags.HasError())
+	assert.False(t, ExecConfigEqual(execConfig, changedConfig))
+}
@@ -2,8 +2,10 @@ package common
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -28,6 +30,15 @@ type InstanceFileModel struct {
 	DirectoryMode types.String `tfsdk:"directory_mode"`
 	CreateDirs    types.Bool   `tfsdk:"create_directories"`
 	Append        types.Bool   `tfsdk:"append"`
+	TrackDrift    types.Bool   `tfsdk:"track_drift"`
+	Template      types.Bool   `tfsdk:"template"`
+
+	// Recursive upload of a source directory.
+	Recursive        types.Bool   `tfsdk:"recursive"`
+	Include          types.List   `tfsdk:"include"`
+	Exclude          types.List   `tfsdk:"exclude"`
+	DeleteExtraneous types.Bool   `tfsdk:"delete_extraneous"`
+	SourceHash       types.String `tfsdk:"source_hash"`
 }
 
 type fileInfo struct {
@@ -74,6 +85,10 @@ func InstanceFileUpload(server incus.InstanceServer, instanceName string, file I
 		return fmt.Errorf("File %q and %q are mutually exclusive.", "content", "source_path")
 	}
 
+	if file.Recursive.ValueBool() {
+		return uploadDirectory(instanceFileTarget(server, instanceName), file)
+	}
+
 	targetPath := file.TargetPath.ValueString()
 
 	fileMode := file.Mode.ValueString()
@@ -248,6 +263,10 @@ func VolumeFileUpload(server incus.InstanceServer, pool, volumeType, volumeName
 		return fmt.Errorf("File %q and %q are mutually exclusive.", "content", "source_path")
 	}
 
+	if file.Recursive.ValueBool() {
+		return uploadDirectory(volumeFileTarget(server, pool, volumeType, volumeName), file)
+	}
+
 	targetPath := file.TargetPath.ValueString()
 
 	fileMode := file.Mode.ValueString()
@@ -350,6 +369,10 @@ func volumeRecursiveMkdir(server incus.InstanceServer, pool, volumeType, volumeN
 
 // HasFileContentChanged determines if the content or source path of the new file differs from the corresponding old file.
 func HasFileContentChanged(newFile InstanceFileModel, oldFile InstanceFileModel) bool {
+	if !newFile.SourceHash.Equal(oldFile.SourceHash) {
+		return true
+	}
+
 	hasNewContent := !newFile.Content.IsNull()
 	hasOldContent := !oldFile.Content.IsNull()
 	hasNewSourcePath := !newFile.SourcePath.IsNull()
@@ -373,3 +396,169 @@ func HasFilePermissionChanged(newFile InstanceFileModel, oldFile InstanceFileMod
 		newFile.UserID.ValueInt64() != oldFile.UserID.ValueInt64() ||
 		newFile.GroupID.ValueInt64() != oldFile.GroupID.ValueInt64()
 }
+
+// SyncInstanceFiles updates the files with track_drift enabled to reflect
+// the files found in the instance. See syncFiles for details.
+func SyncInstanceFiles(ctx context.Context, server incus.InstanceServer, instanceName string, fileSet types.Set) (types.Set, diag.Diagnostics) {
+	return syncFiles(ctx, fileSet, func(path string) (io.ReadCloser, *incus.InstanceFileResponse, error) {
+		return server.GetInstanceFile(instanceName, path)
+	})
+}
+
+// SyncVolumeFiles updates the files with track_drift enabled to reflect
+// the files found in the storage volume. See syncFiles for details.
+func SyncVolumeFiles(ctx context.Context, server incus.InstanceServer, pool, volumeType, volumeName string, fileSet types.Set) (types.Set, diag.Diagnostics) {
+	return syncFiles(ctx, fileSet, func(path string) (io.ReadCloser, *incus.InstanceFileResponse, error) {
+		return server.GetStorageVolumeFile(pool, volumeType, volumeName, path)
+	})
+}
+
+// syncFiles fetches the files with track_drift enabled using the supplied
+// getFile callback and updates them with the differences found, such that
+// they are uploaded again on the next apply. Files which no longer exist are
+// removed from the set.
+func syncFiles(ctx context.Context, fileSet types.Set, getFile func(path string) (io.ReadCloser, *incus.InstanceFileResponse, error)) (types.Set, diag.Diagnostics) {
+	if fileSet.IsNull() || fileSet.IsUnknown() {
+		return fileSet, nil
+	}
+
+	files := make([]InstanceFileModel, 0, len(fileSet.Elements()))
+	diags := fileSet.ElementsAs(ctx, &files, false)
+	if diags.HasError() {
+		return fileSet, diags
+	}
+
+	syncedFiles := make([]InstanceFileModel, 0, len(files))
+	for _, file := range files {
+		if !isFileDriftTracked(file) {
+			syncedFiles = append(syncedFiles, file)
+			continue
+		}
+
+		targetPath := file.TargetPath.ValueString()
+		content, info, err := getFile(targetPath)
+		if err != nil {
+			if tfierrors.IsNotFoundError(err) {
+				continue
+			}
+
+			diags.AddError(fmt.Sprintf("Failed to retrieve file %q", targetPath), err.Error())
+			return fileSet, diags
+		}
+
+		file, err = syncFile(file, content, info)
+		if content != nil {
+			err = errors.Join(err, content.Close())
+		}
+
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Failed to check file %q for drift", targetPath), err.Error())
+			return fileSet, diags
+		}
+
+		syncedFiles = append(syncedFiles, file)
+	}
+
+	syncedFileSet, diags := types.SetValueFrom(ctx, fileSet.ElementType(ctx), syncedFiles)
+	if diags.HasError() {
+		return fileSet, diags
+	}
+
+	return syncedFileSet, nil
+}
+
+// syncFile returns the file updated with the hash of the actual content,
+// mode and ownership. A hash differing from the planned source hash results in
+// the file being uploaded again. The content itself is never stored in the
+// state. The mode is only compared, if it is set explicitly.
+func syncFile(file InstanceFileModel, content io.Reader, info *incus.InstanceFileResponse) (InstanceFileModel, error) {
+	if info.Type != "file" {
+		return file, fmt.Errorf("%q is not a regular file but of type %q", file.TargetPath.ValueString(), info.Type)
+	}
+
+	hash := sha256.New()
+	if content != nil {
+		_, err := io.Copy(hash, content)
+		if err != nil {
+			return file, err
+		}
+	}
+
+	file.SourceHash = types.StringValue(fmt.Sprintf("%x", hash.Sum(nil)))
+
+	if file.Mode.ValueString() != "" {
+		mode, err := strconv.ParseUint(file.Mode.ValueString(), 8, 32)
+		if err != nil {
+			return file, fmt.Errorf("Failed to parse file mode: %v", err)
+		}
+
+		if int(mode) != info.Mode {
+			file.Mode = types.StringValue(fmt.Sprintf("%04o", info.Mode))
+		}
+	}
+
+	if file.UserID.ValueInt64() != info.UID {
+		file.UserID = types.Int64Value(info.UID)
+	}
+
+	if file.GroupID.ValueInt64() != info.GID {
+		file.GroupID = types.Int64Value(info.GID)
+	}
+
+	return file, nil
+}
+
+// isFileDriftTracked returns true if the file is compared with the file
+// found in the instance or storage volume. Appended and templated content can
+// not be compared with the file and directories are only compared using the
+// hash of the source directory.
+func isFileDriftTracked(file InstanceFileModel) bool {
+	return file.TrackDrift.ValueBool() && !file.Append.ValueBool() && !file.Template.ValueBool() && !file.Recursive.ValueBool()
+}
+
+// planFileHash returns the SHA-256 hash of the content, which is uploaded
+// for a file with track_drift enabled. Null is returned, if drift is not
+// tracked for the file or the content is not known yet.
+func planFileHash(file InstanceFileModel) types.String {
+	if !isFileDriftTracked(file) || file.Content.IsUnknown() || file.SourcePath.IsUnknown() {
+		return types.StringNull()
+	}
+
+	hash, ok, err := expectedFileHash(file)
+	if err != nil || !ok {
+		return types.StringNull()
+	}
+
+	return types.StringValue(fmt.Sprintf("%x", hash))
+}
+
+// expectedFileHash returns the SHA-256 hash of the content, which is
+// uploaded for the file. False is returned, if the source file can not be
+// read, in which case the content can not be compared.
+func expectedFileHash(file InstanceFileModel) ([]byte, bool, error) {
+	hash := sha256.New()
+
+	if file.SourcePath.IsNull() {
+		_, _ = hash.Write([]byte(file.Content.ValueString()))
+		return hash.Sum(nil), true, nil
+	}
+
+	path, err := homedir.Expand(file.SourcePath.ValueString())
+	if err != nil {
+		return nil, false, fmt.Errorf("Unable to determine source file path: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	defer func() { _ = f.Close() }()
+
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		return nil, false, fmt.Errorf("Unable to read source file: %v", err)
+	}
+
+	return hash.Sum(nil), true, nil
+}
@@ -0,0 +1,511 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/mitchellh/go-homedir"
+)
+
+// fileTarget provides access to the files of an instance or a storage volume.
+type fileTarget struct {
+	getFile    func(path string) (io.ReadCloser, *incus.InstanceFileResponse, error)
+	createFile func(path string, args incus.InstanceFileArgs) error
+	deleteFile func(path string) error
+}
+
+func instanceFileTarget(server incus.InstanceServer, instanceName string) fileTarget {
+	return fileTarget{
+		getFile: func(path string) (io.ReadCloser, *incus.InstanceFileResponse, error) {
+			return server.GetInstanceFile(instanceName, path)
+		},
+		createFile: func(path string, args incus.InstanceFileArgs) error {
+			return server.CreateInstanceFile(instanceName, path, args)
+		},
+		deleteFile: func(path string) error {
+			return InstanceFileDelete(server, instanceName, path)
+		},
+	}
+}
+
+func volumeFileTarget(server incus.InstanceServer, pool, volumeType, volumeName string) fileTarget {
+	return fileTarget{
+		getFile: func(path string) (io.ReadCloser, *incus.InstanceFileResponse, error) {
+			return server.GetStorageVolumeFile(pool, volumeType, volumeName, path)
+		},
+		createFile: func(path string, args incus.InstanceFileArgs) error {
+			return server.CreateStorageVolumeFile(pool, volumeType, volumeName, path, args)
+		},
+		deleteFile: func(path string) error {
+			return VolumeFileDelete(server, pool, volumeType, volumeName, path)
+		},
+	}
+}
+
+// stat returns the type of the file at the given path.
+func (t fileTarget) stat(path string) (fileInfo, error) {
+	content, resp, err := t.getFile(path)
+	if err != nil {
+		return fileInfo{}, err
+	}
+
+	if content != nil {
+		_ = content.Close()
+	}
+
+	return fileInfo{Type: resp.Type}, nil
+}
+
+// sourceFile is a file found within the source directory of a file block.
+type sourceFile struct {
+	// Path relative to the source directory, using forward slashes.
+	RelPath string
+
+	// Path of the file on the local file system.
+	LocalPath string
+}
+
+// fileFilter selects the files of a source directory using the include and
+// exclude patterns of a file block.
+type fileFilter struct {
+	Include []string
+	Exclude []string
+}
+
+func newFileFilter(file InstanceFileModel) (fileFilter, error) {
+	var filter fileFilter
+
+	diags := file.Include.ElementsAs(context.Background(), &filter.Include, false)
+	if diags.HasError() {
+		return filter, fmt.Errorf("Failed to read include patterns")
+	}
+
+	diags = file.Exclude.ElementsAs(context.Background(), &filter.Exclude, false)
+	if diags.HasError() {
+		return filter, fmt.Errorf("Failed to read exclude patterns")
+	}
+
+	for _, pattern := range append(filter.Include, filter.Exclude...) {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return filter, fmt.Errorf("Invalid pattern %q: %v", pattern, err)
+		}
+	}
+
+	return filter, nil
+}
+
+// IsExcluded returns true if the file or directory matches an exclude
+// pattern.
+func (f fileFilter) IsExcluded(relPath string) bool {
+	return matchAnyPattern(f.Exclude, relPath)
+}
+
+// IsIncluded returns true if the file is not excluded and either matches an
+// include pattern or no include patterns are set.
+func (f fileFilter) IsIncluded(relPath string) bool {
+	if f.IsExcluded(relPath) {
+		return false
+	}
+
+	return len(f.Include) == 0 || matchAnyPattern(f.Include, relPath)
+}
+
+// matchAnyPattern returns true if the relative path matches any of the
+// patterns. Patterns without a slash are also matched against the base name,
+// such that e.g. "*.conf" matches files in all directories.
+func matchAnyPattern(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		ok, _ := path.Match(pattern, relPath)
+		if !ok && !strings.Contains(pattern, "/") {
+			ok, _ = path.Match(pattern, path.Base(relPath))
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// walkSourceDirectory returns the regular files within the source directory
+// of the file block, which are selected by its include and exclude patterns,
+// in lexical order.
+func walkSourceDirectory(file InstanceFileModel) ([]sourceFile, error) {
+	root, err := homedir.Expand(file.SourcePath.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to determine source directory path: %v", err)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read source directory: %v", err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("Source path %q is not a directory, which is required with %q", file.SourcePath.ValueString(), "recursive")
+	}
+
+	filter, err := newFileFilter(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []sourceFile
+	err = filepath.WalkDir(root, func(localPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if localPath == root {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, localPath)
+		if err != nil {
+			return err
+		}
+
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if filter.IsExcluded(relPath) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		// Symlinks and special files are skipped.
+		if !d.Type().IsRegular() || !filter.IsIncluded(relPath) {
+			return nil
+		}
+
+		files = append(files, sourceFile{RelPath: relPath, LocalPath: localPath})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read source directory: %v", err)
+	}
+
+	return files, nil
+}
+
+// hashSourceDirectory returns the SHA-256 hash of the paths and contents of
+// the files selected from the source directory of the file block.
+func hashSourceDirectory(file InstanceFileModel) (string, error) {
+	files, err := walkSourceDirectory(file)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, f := range files {
+		fileHash, err := hashLocalFile(f.LocalPath)
+		if err != nil {
+			return "", err
+		}
+
+		_, _ = fmt.Fprintf(hash, "%s\x00%x\n", f.RelPath, fileHash)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func hashLocalFile(localPath string) ([]byte, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read source file: %v", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, f)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read source file: %v", err)
+	}
+
+	return hash.Sum(nil), nil
+}
+
+// PlanFileSourceHashes validates the recursive settings of the file blocks
+// and sets the source hash of the files uploaded from a directory or with
+// track_drift enabled. This way, changes within the directory or to the file
+// in the target result in the files being uploaded again.
+func PlanFileSourceHashes(ctx context.Context, fileSet types.Set) (types.Set, diag.Diagnostics) {
+	if fileSet.IsNull() || fileSet.IsUnknown() {
+		return fileSet, nil
+	}
+
+	files := make([]InstanceFileModel, 0, len(fileSet.Elements()))
+	diags := fileSet.ElementsAs(ctx, &files, false)
+	if diags.HasError() {
+		return fileSet, diags
+	}
+
+	for i, file := range files {
+		targetPath := file.TargetPath.ValueString()
+
+		if !file.Recursive.ValueBool() {
+			if !file.Include.IsNull() || !file.Exclude.IsNull() || file.DeleteExtraneous.ValueBool() {
+				diags.AddError(
+					fmt.Sprintf("Invalid file %q", targetPath),
+					fmt.Sprintf("%q, %q and %q can only be used with %q.", "include", "exclude", "delete_extraneous", "recursive"),
+				)
+			}
+
+			files[i].SourceHash = planFileHash(file)
+			continue
+		}
+
+		if file.SourcePath.IsNull() {
+			diags.AddError(
+				fmt.Sprintf("Invalid file %q", targetPath),
+				fmt.Sprintf("%q requires %q to be set to a directory.", "recursive", "source_path"),
+			)
+
+			continue
+		}
+
+		if file.SourcePath.IsUnknown() || file.Include.IsUnknown() || file.Exclude.IsUnknown() {
+			files[i].SourceHash = types.StringUnknown()
+			continue
+		}
+
+		hash, err := hashSourceDirectory(file)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Invalid file %q", targetPath), err.Error())
+			continue
+		}
+
+		files[i].SourceHash = types.StringValue(hash)
+	}
+
+	if diags.HasError() {
+		return fileSet, diags
+	}
+
+	return types.SetValueFrom(ctx, fileSet.ElementType(ctx), files)
+}
+
+// uploadDirectory uploads the files selected from the source directory of the
+// file block into the target directory. Missing directories are created.
+// If delete_extraneous is set, files in the target directory, which no longer
+// exist in the source directory, are deleted.
+func uploadDirectory(target fileTarget, file InstanceFileModel) error {
+	files, err := walkSourceDirectory(file)
+	if err != nil {
+		return err
+	}
+
+	fileMode := file.Mode.ValueString()
+	if fileMode == "" {
+		fileMode = "0755"
+	}
+
+	mode, err := strconv.ParseUint(fileMode, 8, 32)
+	if err != nil {
+		return fmt.Errorf("Failed to parse file mode: %v", err)
+	}
+
+	directoryMode := file.DirectoryMode.ValueString()
+	if directoryMode == "" {
+		directoryMode = "0755"
+	}
+
+	dirMode, err := strconv.ParseUint(directoryMode, 8, 32)
+	if err != nil {
+		return fmt.Errorf("Failed to parse file mode: %v", err)
+	}
+
+	dirArgs := incus.InstanceFileArgs{
+		Type: "directory",
+		Mode: int(dirMode),
+		UID:  file.UserID.ValueInt64(),
+		GID:  file.GroupID.ValueInt64(),
+	}
+
+	targetRoot := file.TargetPath.ValueString()
+	err = recursiveMkdir(targetRoot, dirArgs, target.stat, target.createFile)
+	if err != nil {
+		return fmt.Errorf("Could not create directory %q: %v", targetRoot, err)
+	}
+
+	createdDirs := map[string]bool{path.Clean(targetRoot): true}
+
+	for _, f := range files {
+		targetPath := path.Join(targetRoot, f.RelPath)
+
+		targetDir := path.Dir(targetPath)
+		if !createdDirs[targetDir] {
+			err := recursiveMkdir(targetDir, dirArgs, target.stat, target.createFile)
+			if err != nil {
+				return fmt.Errorf("Could not create directory %q: %v", targetDir, err)
+			}
+
+			createdDirs[targetDir] = true
+		}
+
+		// Delete the old file first otherwise mode and ownership changes
+		// will not be applied.
+		err := target.deleteFile(targetPath)
+		if err != nil {
+			return fmt.Errorf("Could not delete file %q: %v", targetPath, err)
+		}
+
+		err = uploadLocalFile(target, f.LocalPath, targetPath, incus.InstanceFileArgs{
+			Type:      "file",
+			Mode:      int(mode),
+			UID:       file.UserID.ValueInt64(),
+			GID:       file.GroupID.ValueInt64(),
+			WriteMode: "overwrite",
+		})
+		if err != nil {
+			return fmt.Errorf("Could not upload file %q: %v", targetPath, err)
+		}
+	}
+
+	if file.DeleteExtraneous.ValueBool() {
+		filter, err := newFileFilter(file)
+		if err != nil {
+			return err
+		}
+
+		sourceFiles := make(map[string]bool, len(files))
+		for _, f := range files {
+			sourceFiles[f.RelPath] = true
+		}
+
+		_, err = deleteExtraneousFiles(target, targetRoot, "", filter, sourceFiles)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func uploadLocalFile(target fileTarget, localPath string, targetPath string, args incus.InstanceFileArgs) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("Unable to read source file: %v", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	args.Content = f
+
+	return target.createFile(targetPath, args)
+}
+
+// deleteExtraneousFiles deletes the files within the target directory, which
+// are not part of the source files. Files not selected by the filter are
+// kept. Directories are deleted once they are empty and do not contain any
+// source files. The number of remaining entries in the directory is returned.
+func deleteExtraneousFiles(target fileTarget, targetRoot string, relDir string, filter fileFilter, sourceFiles map[string]bool) (int, error) {
+	dir := path.Join(targetRoot, relDir)
+
+	content, resp, err := target.getFile(dir)
+	if err != nil {
+		return 0, fmt.Errorf("Could not list directory %q: %v", dir, err)
+	}
+
+	if content != nil {
+		_ = content.Close()
+	}
+
+	remaining := len(resp.Entries)
+	for _, entry := range resp.Entries {
+		relPath := path.Join(relDir, entry)
+		targetPath := path.Join(targetRoot, relPath)
+
+		if filter.IsExcluded(relPath) || sourceFiles[relPath] {
+			continue
+		}
+
+		info, err := target.stat(targetPath)
+		if err != nil {
+			return 0, fmt.Errorf("Could not retrieve file %q: %v", targetPath, err)
+		}
+
+		if info.Type == "directory" {
+			entries, err := deleteExtraneousFiles(target, targetRoot, relPath, filter, sourceFiles)
+			if err != nil {
+				return 0, err
+			}
+
+			if entries > 0 {
+				continue
+			}
+		} else if !filter.IsIncluded(relPath) {
+			continue
+		}
+
+		err = target.deleteFile(targetPath)
+		if err != nil {
+			return 0, fmt.Errorf("Could not delete file %q: %v", targetPath, err)
+		}
+
+		remaining--
+	}
+
+	return remaining, nil
+}
+
+// deleteDirectoryFiles deletes the files selected from the source directory
+// of the file block from the target directory. Other files and the
+// directories are kept.
+func deleteDirectoryFiles(target fileTarget, file InstanceFileModel) error {
+	// Nothing is known about the uploaded files, if the source directory
+	// is gone.
+	root, err := homedir.Expand(file.SourcePath.ValueString())
+	if err != nil {
+		return fmt.Errorf("Unable to determine source directory path: %v", err)
+	}
+
+	_, err = os.Stat(root)
+	if err != nil && os.IsNotExist(err) {
+		return nil
+	}
+
+	files, err := walkSourceDirectory(file)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		targetPath := path.Join(file.TargetPath.ValueString(), f.RelPath)
+
+		err := target.deleteFile(targetPath)
+		if err != nil {
+			return fmt.Errorf("Could not delete file %q: %v", targetPath, err)
+		}
+	}
+
+	return nil
+}
+
+// InstanceDirectoryDelete deletes the files uploaded recursively from the
+// source directory of the file block from an instance.
+func InstanceDirectoryDelete(server incus.InstanceServer, instanceName string, file InstanceFileModel) error {
+	return deleteDirectoryFiles(instanceFileTarget(server, instanceName), file)
+}
+
+// VolumeDirectoryDelete deletes the files uploaded recursively from the
+// source directory of the file block from a storage volume.
+func VolumeDirectoryDelete(server incus.InstanceServer, pool, volumeType, volumeName string, file InstanceFileModel) error {
+	return deleteDirectoryFiles(volumeFileTarget(server, pool, volumeType, volumeName), file)
+}
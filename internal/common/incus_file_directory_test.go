@@ -0,0 +1,179 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchAnyPattern(t *testing.T) {
+	tests := []struct {
+		Patterns []string
+		Path     string
+		Want     bool
+	}{
+		{Patterns: nil, Path: "a.conf", Want: false},
+		{Patterns: []string{"*.conf"}, Path: "a.conf", Want: true},
+		{Patterns: []string{"*.conf"}, Path: "sub/a.conf", Want: true},
+		{Patterns: []string{"sub/*.conf"}, Path: "sub/a.conf", Want: true},
+		{Patterns: []string{"sub/*.conf"}, Path: "other/a.conf", Want: false},
+		{Patterns: []string{"*.txt", "cache"}, Path: "sub/cache", Want: true},
+		{Patterns: []string{"*.txt"}, Path: "a.conf", Want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			assert.Equal(t, test.Want, matchAnyPattern(test.Patterns, test.Path))
+		})
+	}
+}
+
+func TestWalkSourceDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"a.conf":         "a",
+		"b.txt":          "b",
+		"sub/c.conf":     "c",
+		"cache/d.conf":   "d",
+		"sub/deep/e.txt": "e",
+	})
+
+	tests := []struct {
+		Name    string
+		Include []string
+		Exclude []string
+		Want    []string
+	}{
+		{
+			Name: "All files",
+			Want: []string{"a.conf", "b.txt", "cache/d.conf", "sub/c.conf", "sub/deep/e.txt"},
+		},
+		{
+			Name:    "Include",
+			Include: []string{"*.conf"},
+			Want:    []string{"a.conf", "cache/d.conf", "sub/c.conf"},
+		},
+		{
+			Name:    "Exclude directory",
+			Exclude: []string{"cache"},
+			Want:    []string{"a.conf", "b.txt", "sub/c.conf", "sub/deep/e.txt"},
+		},
+		{
+			Name:    "Include and exclude",
+			Include: []string{"*.conf"},
+			Exclude: []string{"sub/*"},
+			Want:    []string{"a.conf", "cache/d.conf"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file := testDirectoryFile(t, root, test.Include, test.Exclude)
+
+			files, err := walkSourceDirectory(file)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(files))
+			for _, f := range files {
+				got = append(got, f.RelPath)
+				assert.Equal(t, filepath.Join(root, filepath.FromSlash(f.RelPath)), f.LocalPath)
+			}
+
+			assert.Equal(t, test.Want, got)
+		})
+	}
+}
+
+func TestWalkSourceDirectory_invalid(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{"a.conf": "a"})
+
+	_, err := walkSourceDirectory(testDirectoryFile(t, filepath.Join(root, "a.conf"), nil, nil))
+	assert.Error(t, err, "Source path is not a directory")
+
+	_, err = walkSourceDirectory(testDirectoryFile(t, filepath.Join(root, "missing"), nil, nil))
+	assert.Error(t, err, "Source path does not exist")
+
+	_, err = walkSourceDirectory(testDirectoryFile(t, root, []string{"[a-"}, nil))
+	assert.Error(t, err, "Invalid pattern")
+}
+
+func TestHashSourceDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeTestFiles(t, root, map[string]string{
+		"a.conf":     "a",
+		"sub/b.conf": "b",
+	})
+
+	file := testDirectoryFile(t, root, nil, nil)
+
+	hash, err := hashSourceDirectory(file)
+	require.NoError(t, err)
+
+	// The hash is stable.
+	again, err := hashSourceDirectory(file)
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	// Changed content results in a different hash.
+	writeTestFiles(t, root, map[string]string{"sub/b.conf": "changed"})
+	changed, err := hashSourceDirectory(file)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+
+	// Renamed files result in a different hash.
+	require.NoError(t, os.Rename(filepath.Join(root, "sub", "b.conf"), filepath.Join(root, "sub", "c.conf")))
+	renamed, err := hashSourceDirectory(file)
+	require.NoError(t, err)
+	assert.NotEqual(t, changed, renamed)
+
+	// Excluded files do not affect the hash.
+	excluded := testDirectoryFile(t, root, nil, []string{"*.txt"})
+	before, err := hashSourceDirectory(excluded)
+	require.NoError(t, err)
+
+	writeTestFiles(t, root, map[string]string{"ignored.txt": "ignored"})
+	after, err := hashSourceDirectory(excluded)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+}
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		localPath := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(localPath), 0o700))
+		require.NoError(t, os.WriteFile(localPath, []byte(content), 0o600))
+	}
+}
+
+func testDirectoryFile(t *testing.T, sourcePath string, include []string, exclude []string) InstanceFileModel {
+	t.Helper()
+
+	toList := func(patterns []string) types.List {
+		if patterns == nil {
+			return types.ListNull(types.StringType)
+		}
+
+		values := make([]attr.Value, 0, len(patterns))
+		for _, p := range patterns {
+			values = append(values, types.StringValue(p))
+		}
+
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	return InstanceFileModel{
+		SourcePath: types.StringValue(sourcePath),
+		TargetPath: types.StringValue("/opt/app"),
+		Recursive:  types.BoolValue(true),
+		Include:    toList(include),
+		Exclude:    toList(exclude),
+	}
+}
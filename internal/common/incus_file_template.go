@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// FileTemplateData returns the data available to templated file content.
+// The keys match the attributes of the instance resource, such that e.g.
+// "{{ .ipv4_address }}" renders the IPv4 address of the instance.
+func FileTemplateData(ctx context.Context, instance *api.Instance, instanceState *api.InstanceState, ipv4Address string) (map[string]any, diag.Diagnostics) {
+	interfaceMap, diags := ToInterfaceMapType(ctx, instanceState.Network, instance.Config)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	interfaceModels := make(map[string]InterfaceModel, len(interfaceMap.Elements()))
+	diags = interfaceMap.ElementsAs(ctx, &interfaceModels, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	interfaces := make(map[string]any, len(interfaceModels))
+	for name, inf := range interfaceModels {
+		ipModels := make([]IPModel, 0, len(inf.IPAddresses.Elements()))
+		diags = inf.IPAddresses.ElementsAs(ctx, &ipModels, false)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		addresses := make([]map[string]string, 0, len(ipModels))
+		for _, ip := range ipModels {
+			addresses = append(addresses, map[string]string{
+				"address": ip.Address.ValueString(),
+				"family":  ip.Family.ValueString(),
+				"scope":   ip.Scope.ValueString(),
+			})
+		}
+
+		interfaces[name] = map[string]any{
+			"name":         inf.RealName.ValueString(),
+			"state":        inf.State.ValueString(),
+			"type":         inf.Type.ValueString(),
+			"ip_addresses": addresses,
+		}
+	}
+
+	config := instance.Config
+	if config == nil {
+		config = map[string]string{}
+	}
+
+	data := map[string]any{
+		"name":         instance.Name,
+		"project":      instance.Project,
+		"ipv4_address": ipv4Address,
+		"config":       config,
+		"interfaces":   interfaces,
+	}
+
+	return data, nil
+}
+
+// ValidateFileTemplate ensures that template mode is only used with content
+// and that the content is a valid template.
+func ValidateFileTemplate(file InstanceFileModel) error {
+	if !file.Template.ValueBool() {
+		return nil
+	}
+
+	if !file.SourcePath.IsNull() || file.Recursive.ValueBool() {
+		return fmt.Errorf("%q can only be used with %q", "template", "content")
+	}
+
+	if file.Content.IsUnknown() {
+		return nil
+	}
+
+	_, err := parseFileTemplate(file)
+	return err
+}
+
+// RenderFileTemplate renders the content of a templated file using the given
+// data. Files which are not templated are returned unchanged.
+func RenderFileTemplate(file InstanceFileModel, data map[string]any) (InstanceFileModel, error) {
+	if !file.Template.ValueBool() {
+		return file, nil
+	}
+
+	tmpl, err := parseFileTemplate(file)
+	if err != nil {
+		return file, err
+	}
+
+	var content strings.Builder
+	err = tmpl.Execute(&content, data)
+	if err != nil {
+		return file, fmt.Errorf("Failed to render template: %v", err)
+	}
+
+	file.Content = types.StringValue(content.String())
+	return file, nil
+}
+
+// HasFileTemplate returns true if any of the files is templated.
+func HasFileTemplate(files map[string]InstanceFileModel) bool {
+	for _, f := range files {
+		if f.Template.ValueBool() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseFileTemplate(file InstanceFileModel) (*template.Template, error) {
+	tmpl, err := template.New(file.TargetPath.ValueString()).Option("missingkey=error").Parse(file.Content.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse template: %v", err)
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTemplateData(t *testing.T) {
+	instance := &api.Instance{
+		Name:    "c1",
+		Project: "default",
+	}
+
+	instance.Config = map[string]string{
+		"user.role":            "web",
+		"volatile.eth0.hwaddr": "10:66:6a:00:00:01",
+	}
+
+	instanceState := &api.InstanceState{
+		Network: map[string]api.InstanceStateNetwork{
+			"eth0": {
+				Hwaddr: "10:66:6a:00:00:01",
+				State:  "up",
+				Type:   "broadcast",
+				Addresses: []api.InstanceStateNetworkAddress{
+					{Family: "inet", Address: "10.0.0.2", Scope: "global"},
+				},
+			},
+		},
+	}
+
+	data, diags := FileTemplateData(context.Background(), instance, instanceState, "10.0.0.2")
+	require.False(t, diags.HasError())
+
+	file := InstanceFileModel{
+		Content:    types.StringValue(`{{ .name }}.{{ .project }} {{ .ipv4_address }} {{ index .config "user.role" }} {{ (index .interfaces.eth0.ip_addresses 0).address }}`),
+		TargetPath: types.StringValue("/etc/hosts.d/self"),
+		Template:   types.BoolValue(true),
+	}
+
+	got, err := RenderFileTemplate(file, data)
+	require.NoError(t, err)
+	assert.Equal(t, "c1.default 10.0.0.2 web 10.0.0.2", got.Content.ValueString())
+}
+
+func TestRenderFileTemplate(t *testing.T) {
+	data := map[string]any{"name": "c1"}
+
+	tests := []struct {
+		Name     string
+		Content  string
+		Template bool
+		Want     string
+		WantErr  bool
+	}{
+		{
+			Name:     "Template",
+			Content:  "name={{ .name }}",
+			Template: true,
+			Want:     "name=c1",
+		},
+		{
+			Name:    "Not a template",
+			Content: "name={{ .name }}",
+			Want:    "name={{ .name }}",
+		},
+		{
+			Name:     "Missing key",
+			Content:  "{{ .missing }}",
+			Template: true,
+			WantErr:  true,
+		},
+		{
+			Name:     "Invalid template",
+			Content:  "{{ .name",
+			Template: true,
+			WantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file := InstanceFileModel{
+				Content:    types.StringValue(test.Content),
+				TargetPath: types.StringValue("/tmp/test"),
+				Template:   types.BoolValue(test.Template),
+			}
+
+			got, err := RenderFileTemplate(file, data)
+			if test.WantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Want, got.Content.ValueString())
+		})
+	}
+}
+
+func TestValidateFileTemplate(t *testing.T) {
+	file := InstanceFileModel{
+		Content:    types.StringValue("{{ .name }}"),
+		SourcePath: types.StringNull(),
+		TargetPath: types.StringValue("/tmp/test"),
+		Template:   types.BoolValue(true),
+	}
+
+	assert.NoError(t, ValidateFileTemplate(file))
+
+	unknown := file
+	unknown.Content = types.StringUnknown()
+	assert.NoError(t, ValidateFileTemplate(unknown))
+
+	invalid := file
+	invalid.Content = types.StringValue("{{ .name")
+	assert.Error(t, ValidateFileTemplate(invalid))
+
+	source := file
+	source.Content = types.StringNull()
+	source.SourcePath = types.StringValue("/tmp/source")
+	assert.Error(t, ValidateFileTemplate(source))
+}
@@ -0,0 +1,171 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncFile(t *testing.T) {
+	file := InstanceFileModel{
+		Content:    types.StringValue("hello"),
+		SourcePath: types.StringNull(),
+		TargetPath: types.StringValue("/tmp/hello.txt"),
+		UserID:     types.Int64Null(),
+		GroupID:    types.Int64Null(),
+		Mode:       types.StringValue("0644"),
+		TrackDrift: types.BoolValue(true),
+		SourceHash: types.StringValue("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+	}
+
+	info := incus.InstanceFileResponse{Type: "file", Mode: 0o644}
+
+	tests := []struct {
+		Name    string
+		File    InstanceFileModel
+		Content string
+		Info    incus.InstanceFileResponse
+		Want    InstanceFileModel
+	}{
+		{
+			Name:    "Unchanged content",
+			File:    file,
+			Content: "hello",
+			Info:    info,
+			Want:    file,
+		},
+		{
+			Name:    "Changed content",
+			File:    file,
+			Content: "changed",
+			Info:    info,
+			Want: func() InstanceFileModel {
+				f := file
+				f.SourceHash = types.StringValue("d67e2e944994496c8d8ec76eed0cf9f09679448d584b532bebf941852a37f5ed")
+				return f
+			}(),
+		},
+		{
+			Name:    "Changed binary content",
+			File:    file,
+			Content: "\xff\xfe",
+			Info:    info,
+			Want: func() InstanceFileModel {
+				f := file
+				f.SourceHash = types.StringValue("b3d510ef04275ca8e698e5b3cbb0ece3949ef9252f0cdc839e9ee347409a2209")
+				return f
+			}(),
+		},
+		{
+			Name:    "Changed mode and ownership",
+			File:    file,
+			Content: "hello",
+			Info:    incus.InstanceFileResponse{Type: "file", Mode: 0o600, UID: 1000, GID: 1000},
+			Want: func() InstanceFileModel {
+				f := file
+				f.Mode = types.StringValue("0600")
+				f.UserID = types.Int64Value(1000)
+				f.GroupID = types.Int64Value(1000)
+				return f
+			}(),
+		},
+		{
+			Name:    "Mode with different notation",
+			File:    func() InstanceFileModel { f := file; f.Mode = types.StringValue("644"); return f }(),
+			Content: "hello",
+			Info:    info,
+			Want:    func() InstanceFileModel { f := file; f.Mode = types.StringValue("644"); return f }(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := syncFile(test.File, strings.NewReader(test.Content), &test.Info)
+			require.NoError(t, err)
+			assert.Equal(t, test.Want, got)
+		})
+	}
+}
+
+func TestPlanFileHash(t *testing.T) {
+	sourcePath := filepath.Join(t.TempDir(), "source.txt")
+	err := os.WriteFile(sourcePath, []byte("hello"), 0o600)
+	require.NoError(t, err)
+
+	file := InstanceFileModel{
+		Content:    types.StringValue("hello"),
+		SourcePath: types.StringNull(),
+		TrackDrift: types.BoolValue(true),
+	}
+
+	helloHash := types.StringValue("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824")
+
+	tests := []struct {
+		Name string
+		File InstanceFileModel
+		Want types.String
+	}{
+		{
+			Name: "Content",
+			File: file,
+			Want: helloHash,
+		},
+		{
+			Name: "Source file",
+			File: func() InstanceFileModel {
+				f := file
+				f.Content = types.StringNull()
+				f.SourcePath = types.StringValue(sourcePath)
+				return f
+			}(),
+			Want: helloHash,
+		},
+		{
+			Name: "Missing source file",
+			File: func() InstanceFileModel {
+				f := file
+				f.Content = types.StringNull()
+				f.SourcePath = types.StringValue(filepath.Join(t.TempDir(), "missing.txt"))
+				return f
+			}(),
+			Want: types.StringNull(),
+		},
+		{
+			Name: "Unknown content",
+			File: func() InstanceFileModel { f := file; f.Content = types.StringUnknown(); return f }(),
+			Want: types.StringNull(),
+		},
+		{
+			Name: "Drift not tracked",
+			File: func() InstanceFileModel { f := file; f.TrackDrift = types.BoolNull(); return f }(),
+			Want: types.StringNull(),
+		},
+		{
+			Name: "Templated content",
+			File: func() InstanceFileModel { f := file; f.Template = types.BoolValue(true); return f }(),
+			Want: types.StringNull(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Want, planFileHash(test.File))
+		})
+	}
+}
+
+func TestSyncFile_notRegularFile(t *testing.T) {
+	file := InstanceFileModel{
+		Content:    types.StringValue("hello"),
+		TargetPath: types.StringValue("/tmp"),
+	}
+
+	_, err := syncFile(file, nil, &incus.InstanceFileResponse{Type: "directory"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// IdentitySchema returns the identity schema of a resource that is
+// identified by the given required fields within a project and remote.
+// The attributes match the fields of the resource's import ID.
+func IdentitySchema(requiredFields ...string) identityschema.Schema {
+	attributes := map[string]identityschema.Attribute{
+		"project": identityschema.StringAttribute{
+			OptionalForImport: true,
+			Description:       "Name of the project the resource belongs to.",
+		},
+		"remote": identityschema.StringAttribute{
+			OptionalForImport: true,
+			Description:       "Name of the remote the resource belongs to.",
+		},
+	}
+
+	for _, field := range requiredFields {
+		attributes[field] = identityschema.StringAttribute{
+			RequiredForImport: true,
+		}
+	}
+
+	return identityschema.Schema{
+		Attributes: attributes,
+	}
+}
+
+// SetIdentity sets the identity attributes of a resource from the matching
+// attributes of its state. The identity is left untouched if the resource
+// was removed from the state.
+func SetIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, state tfsdk.State) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if identity == nil || state.Raw.IsNull() {
+		return nil
+	}
+
+	for name := range identity.Schema.GetAttributes() {
+		var value types.String
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		diags.Append(identity.SetAttribute(ctx, path.Root(name), value)...)
+	}
+
+	return diags
+}
+
+// ParseImportRequest returns the fields of the resource being imported.
+// They are parsed from the import ID, or taken from the identity attributes
+// when the resource is imported by identity.
+func (m ImportMetadata) ParseImportRequest(ctx context.Context, req resource.ImportStateRequest) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if req.ID != "" || req.Identity == nil {
+		fields, diag := m.ParseImportID(req.ID)
+		if diag != nil {
+			diags.Append(diag)
+		}
+
+		return fields, diags
+	}
+
+	fields := make(map[string]string)
+	for name := range req.Identity.Schema.GetAttributes() {
+		var value types.String
+		diags.Append(req.Identity.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if value.ValueString() != "" {
+			fields[name] = value.ValueString()
+		}
+	}
+
+	for _, field := range m.RequiredFields {
+		if fields[field] == "" {
+			diags.AddError(
+				fmt.Sprintf("Invalid import identity for %s", m.ResourceName),
+				fmt.Sprintf("Identity attribute %q is required.", field),
+			)
+		}
+	}
+
+	return fields, diags
+}
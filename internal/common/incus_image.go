@@ -0,0 +1,18 @@
+package common
+
+import (
+	"strings"
+)
+
+// ParseImageRef splits an image reference in the format "[remote:]image"
+// into the remote name and the image alias or fingerprint. The remote is
+// empty if the reference does not specify one, in which case the image is
+// looked up on the instance's remote.
+func ParseImageRef(ref string) (remote string, image string) {
+	parts := strings.SplitN(ref, ":", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+
+	return "", ref
+}
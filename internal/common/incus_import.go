@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -165,3 +167,62 @@ func newImportIDError(m ImportMetadata, importID string, err error) diag.Diagnos
 		),
 	)
 }
+
+// FormatImportID builds an import ID from remote name, project name, values
+// of the required fields, and options. It is the inverse of ParseImportID,
+// producing an ID in the format:
+//
+//	[remote:][project/]rf1[/rfN][,optKey1=optVal1][,optKeyN=optValN]
+//
+// Empty remote and project are omitted. Options are sorted by key.
+func FormatImportID(remote string, project string, fields []string, options map[string]string) (string, error) {
+	if len(fields) == 0 {
+		return "", fmt.Errorf("Import ID requires at least one field")
+	}
+
+	if strings.ContainsAny(remote, ":/,") {
+		return "", fmt.Errorf("Remote %q must not contain any of \":\", \"/\" or \",\"", remote)
+	}
+
+	if strings.ContainsAny(project, "/,") {
+		return "", fmt.Errorf("Project %q must not contain any of \"/\" or \",\"", project)
+	}
+
+	for _, field := range fields {
+		if field == "" {
+			return "", fmt.Errorf("Import ID requires non-empty values for all fields")
+		}
+
+		if strings.ContainsAny(field, "/,") {
+			return "", fmt.Errorf("Field %q must not contain any of \"/\" or \",\"", field)
+		}
+	}
+
+	id := strings.Join(fields, "/")
+
+	// Project is mandatory when there are multiple fields, even if empty.
+	if project != "" || len(fields) > 1 {
+		id = project + "/" + id
+	}
+
+	// The first colon separates the remote, so prepend an empty remote if
+	// the remaining part contains one.
+	if remote != "" || strings.Contains(id, ":") {
+		id = remote + ":" + id
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		value := options[key]
+		if key == "" || strings.ContainsAny(key, "=,") {
+			return "", fmt.Errorf("Option key %q must be non-empty and not contain any of \"=\" or \",\"", key)
+		}
+
+		if strings.ContainsAny(value, "=,") {
+			return "", fmt.Errorf("Value of option %q must not contain any of \"=\" or \",\"", key)
+		}
+
+		id += fmt.Sprintf(",%s=%s", key, value)
+	}
+
+	return id, nil
+}
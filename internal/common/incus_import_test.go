@@ -311,3 +311,106 @@ func TestSplitImportID_ErrorFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatImportID(t *testing.T) {
+	tests := []struct {
+		Remote      string
+		Project     string
+		Fields      []string
+		Options     map[string]string
+		ImportID    string
+		ErrorString string
+	}{
+		{
+			Fields:   []string{"vm"},
+			ImportID: "vm",
+		},
+		{
+			Project:  "proj",
+			Fields:   []string{"vm"},
+			ImportID: "proj/vm",
+		},
+		{
+			Remote:   "rem",
+			Project:  "proj",
+			Fields:   []string{"vm"},
+			ImportID: "rem:proj/vm",
+		},
+		{
+			Fields:   []string{"pool", "custom", "vol"},
+			ImportID: "/pool/custom/vol",
+		},
+		{
+			Remote:   "rem",
+			Fields:   []string{"vm"},
+			Options:  map[string]string{"type": "vm", "image": "images:alpine/edge"},
+			ImportID: "rem:vm,image=images:alpine/edge,type=vm",
+		},
+		{
+			Fields:   []string{"a:b"},
+			ImportID: ":a:b",
+		},
+		{
+			ErrorString: "Import ID requires at least one field",
+		},
+		{
+			Fields:      []string{""},
+			ErrorString: "Import ID requires non-empty values for all fields",
+		},
+		{
+			Fields:      []string{"a/b"},
+			ErrorString: "Field \"a/b\" must not contain any of \"/\" or \",\"",
+		},
+		{
+			Remote:      "a:b",
+			Fields:      []string{"vm"},
+			ErrorString: "Remote \"a:b\" must not contain any of \":\", \"/\" or \",\"",
+		},
+		{
+			Fields:      []string{"vm"},
+			Options:     map[string]string{"image": "a,b"},
+			ErrorString: "Value of option \"image\" must not contain any of \"=\" or \",\"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ImportID:%q", test.ImportID), func(t *testing.T) {
+			importID, err := FormatImportID(test.Remote, test.Project, test.Fields, test.Options)
+			if test.ErrorString != "" {
+				assert.EqualError(t, err, test.ErrorString)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.ImportID, importID)
+
+			// Ensure the import ID is parsed back into the same values.
+			fieldNames := make([]string, 0, len(test.Fields))
+			expected := map[string]string{}
+			for i, field := range test.Fields {
+				name := fmt.Sprintf("field%d", i)
+				fieldNames = append(fieldNames, name)
+				expected[name] = field
+			}
+
+			optionNames := make([]string, 0, len(test.Options))
+			for key, value := range test.Options {
+				optionNames = append(optionNames, key)
+				expected[key] = value
+			}
+
+			if test.Remote != "" {
+				expected["remote"] = test.Remote
+			}
+
+			if test.Project != "" {
+				expected["project"] = test.Project
+			}
+
+			meta := ImportMetadata{RequiredFields: fieldNames, AllowedOptions: optionNames}
+			result, diag := meta.ParseImportID(importID)
+			assert.Nil(t, diag)
+			assert.Equal(t, expected, result)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package common
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ListSchemaAttributes returns the schema attributes selecting the project
+// and remote to list resources from, which are shared by all list
+// resources.
+func ListSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"project": schema.StringAttribute{
+			Optional:    true,
+			Description: "Name of the project to list the resources from.",
+		},
+
+		"remote": schema.StringAttribute{
+			Optional:    true,
+			Description: "Name of the remote to list the resources from.",
+		},
+	}
+}
+
+// MatchConfig returns true if the config contains all the key/value pairs
+// of the filter.
+func MatchConfig(config map[string]string, filter map[string]string) bool {
+	for k, v := range filter {
+		value, ok := config[k]
+		if !ok || value != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchFilter returns true if the filter is not set or equals the value,
+// ignoring case.
+func MatchFilter(filter types.String, value string) bool {
+	return filter.ValueString() == "" || strings.EqualFold(filter.ValueString(), value)
+}
+
+// NewListResult returns the list result of the resource identified by the
+// given attributes, which are also used as its identity. If the resource
+// itself is requested, its state is populated using the syncState function,
+// as done by the resource's Read. False is returned if the resource no
+// longer exists.
+func NewListResult[T any](ctx context.Context, req list.ListRequest, displayName string, fields map[string]string, syncState func(context.Context, *tfsdk.State, T) diag.Diagnostics) (list.ListResult, bool) {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+
+	state := tfsdk.State{
+		Schema: req.ResourceSchema,
+		Raw:    tftypes.NewValue(req.ResourceSchema.Type().TerraformType(ctx), nil),
+	}
+
+	for k, v := range fields {
+		if v == "" {
+			continue
+		}
+
+		result.Diagnostics.Append(state.SetAttribute(ctx, path.Root(k), v)...)
+	}
+
+	if result.Diagnostics.HasError() {
+		return result, true
+	}
+
+	if req.IncludeResource {
+		var m T
+		result.Diagnostics.Append(state.Get(ctx, &m)...)
+		if result.Diagnostics.HasError() {
+			return result, true
+		}
+
+		result.Diagnostics.Append(syncState(ctx, &state, m)...)
+		if result.Diagnostics.HasError() {
+			return result, true
+		}
+
+		if state.Raw.IsNull() {
+			return result, false
+		}
+
+		result.Resource.Raw = state.Raw
+	}
+
+	result.Diagnostics.Append(SetIdentity(ctx, result.Identity, state)...)
+
+	return result, true
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchConfig(t *testing.T) {
+	config := map[string]string{
+		"user.team":       "infra",
+		"security.nested": "true",
+	}
+
+	tests := []struct {
+		Name   string
+		Filter map[string]string
+		Match  bool
+	}{
+		{
+			Name:   "No filter",
+			Filter: nil,
+			Match:  true,
+		},
+		{
+			Name:   "Matching key",
+			Filter: map[string]string{"user.team": "infra"},
+			Match:  true,
+		},
+		{
+			Name:   "All keys matching",
+			Filter: map[string]string{"user.team": "infra", "security.nested": "true"},
+			Match:  true,
+		},
+		{
+			Name:   "Different value",
+			Filter: map[string]string{"user.team": "web"},
+			Match:  false,
+		},
+		{
+			Name:   "Missing key",
+			Filter: map[string]string{"user.team": "infra", "limits.cpu": "2"},
+			Match:  false,
+		},
+		{
+			Name:   "Empty value of missing key",
+			Filter: map[string]string{"limits.cpu": ""},
+			Match:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Match, MatchConfig(config, test.Filter))
+		})
+	}
+}
+
+func TestMatchFilter(t *testing.T) {
+	assert.True(t, MatchFilter(types.StringNull(), "Running"))
+	assert.True(t, MatchFilter(types.StringValue(""), "Running"))
+	assert.True(t, MatchFilter(types.StringValue("running"), "Running"))
+	assert.False(t, MatchFilter(types.StringValue("stopped"), "Running"))
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ToTimestamp converts a timestamp of type types.String in RFC 3339 format
+// into time. Nil is returned if the timestamp is not set.
+func ToTimestamp(value types.String) (*time.Time, error) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// ToTimestampType converts time into types.String formatted as RFC 3339.
+// If the current value represents the same instant, it is returned as is,
+// so that the format chosen by the user is preserved. Zero time results
+// in a null value.
+func ToTimestampType(t time.Time, current types.String) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+
+	currentTime, err := ToTimestamp(current)
+	if err == nil && currentTime != nil && currentTime.Equal(t) {
+		return current
+	}
+
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
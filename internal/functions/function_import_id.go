@@ -0,0 +1,73 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+)
+
+// ImportIDFunction builds the import ID of an Incus resource.
+type ImportIDFunction struct{}
+
+// NewImportIDFunction returns a new import ID function.
+func NewImportIDFunction() function.Function {
+	return &ImportIDFunction{}
+}
+
+func (f *ImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "import_id"
+}
+
+func (f *ImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the import ID of a resource",
+		Description: "Builds an import ID in the format [remote:][project/]name[,key=value] from its remote, project, required fields and options.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:           "remote",
+				Description:    "Name of the remote. Omitted if null or empty.",
+				AllowNullValue: true,
+			},
+			function.StringParameter{
+				Name:           "project",
+				Description:    "Name of the project. Omitted if null or empty.",
+				AllowNullValue: true,
+			},
+			function.ListParameter{
+				Name:        "fields",
+				Description: "Values of the required fields of the resource, such as [\"name\"] or [\"pool\", \"type\", \"name\"].",
+				ElementType: types.StringType,
+			},
+			function.MapParameter{
+				Name:           "options",
+				Description:    "Import options of the resource, such as {image = \"images:alpine/edge\"}.",
+				ElementType:    types.StringType,
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var remote types.String
+	var project types.String
+	var fields []string
+	var options map[string]string
+
+	resp.Error = req.Arguments.Get(ctx, &remote, &project, &fields, &options)
+	if resp.Error != nil {
+		return
+	}
+
+	importID, err := common.FormatImportID(remote.ValueString(), project.ValueString(), fields, options)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, importID)
+}
@@ -0,0 +1,71 @@
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccImportIDFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImportIDFunction_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("name", "c1"),
+					resource.TestCheckOutput("full", "local:proj/c1,image=images:alpine/edge"),
+					resource.TestCheckOutput("fields", "/pool/custom/vol"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccImportIDFunction_invalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccImportIDFunction_invalid(),
+				ExpectError: regexp.MustCompile(`Field "a/b" must not contain`),
+			},
+		},
+	})
+}
+
+func testAccImportIDFunction_basic() string {
+	return `
+output "name" {
+  value = provider::incus::import_id(null, null, ["c1"], null)
+}
+
+output "full" {
+  value = provider::incus::import_id("local", "proj", ["c1"], { image = "images:alpine/edge" })
+}
+
+output "fields" {
+  value = provider::incus::import_id("", "", ["pool", "custom", "vol"], {})
+}
+`
+}
+
+func testAccImportIDFunction_invalid() string {
+	return `
+output "invalid" {
+  value = provider::incus::import_id(null, null, ["a/b"], null)
+}
+`
+}
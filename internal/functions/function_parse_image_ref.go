@@ -0,0 +1,78 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+)
+
+// imageRefAttrTypes defines the attributes returned by parse_image_ref.
+var imageRefAttrTypes = map[string]attr.Type{
+	"remote": types.StringType,
+	"image":  types.StringType,
+}
+
+// ParseImageRefFunction parses an image reference of an Incus instance.
+type ParseImageRefFunction struct{}
+
+// NewParseImageRefFunction returns a new parse image reference function.
+func NewParseImageRefFunction() function.Function {
+	return &ParseImageRefFunction{}
+}
+
+func (f *ParseImageRefFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_image_ref"
+}
+
+func (f *ParseImageRefFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse an image reference",
+		Description: "Splits an image reference in the format [remote:]image, as used by the image attribute of incus_instance, into an object with the remote and the image alias or fingerprint. The remote is null if the reference does not specify one.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "image",
+				Description: "Image reference to parse, such as \"images:debian/12\".",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: imageRefAttrTypes,
+		},
+	}
+}
+
+func (f *ParseImageRefFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ref string
+
+	resp.Error = req.Arguments.Get(ctx, &ref)
+	if resp.Error != nil {
+		return
+	}
+
+	if ref == "" {
+		resp.Error = function.NewArgumentFuncError(0, "Image reference cannot be empty")
+		return
+	}
+
+	remote, image := common.ParseImageRef(ref)
+
+	remoteValue := types.StringNull()
+	if remote != "" {
+		remoteValue = types.StringValue(remote)
+	}
+
+	result, diags := types.ObjectValue(imageRefAttrTypes, map[string]attr.Value{
+		"remote": remoteValue,
+		"image":  types.StringValue(image),
+	})
+
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
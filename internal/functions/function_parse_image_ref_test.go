@@ -0,0 +1,56 @@
+package functions_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccParseImageRefFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParseImageRefFunction_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("remote", "images"),
+					resource.TestCheckOutput("image", "debian/12"),
+					resource.TestCheckOutput("local_remote_is_null", "true"),
+					resource.TestCheckOutput("local_image", "abcdef"),
+				),
+			},
+		},
+	})
+}
+
+func testAccParseImageRefFunction_basic() string {
+	return `
+locals {
+  remote = provider::incus::parse_image_ref("images:debian/12")
+  local  = provider::incus::parse_image_ref("abcdef")
+}
+
+output "remote" {
+  value = local.remote.remote
+}
+
+output "image" {
+  value = local.remote.image
+}
+
+output "local_remote_is_null" {
+  value = local.local.remote == null
+}
+
+output "local_image" {
+  value = local.local.image
+}
+`
+}
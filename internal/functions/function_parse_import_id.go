@@ -0,0 +1,86 @@
+package functions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+)
+
+// ParseImportIDFunction parses the import ID of an Incus resource.
+type ParseImportIDFunction struct{}
+
+// NewParseImportIDFunction returns a new parse import ID function.
+func NewParseImportIDFunction() function.Function {
+	return &ParseImportIDFunction{}
+}
+
+func (f *ParseImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_import_id"
+}
+
+func (f *ParseImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse the import ID of a resource",
+		Description: "Parses an import ID in the format [remote:][project/]name[,key=value] into a map containing the remote, the project, the required fields and the options. The remote and the project are only present if set in the import ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "import_id",
+				Description: "Import ID to parse.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:        "fields",
+			Description: "Names of the required fields of the resource, such as \"pool\", \"type\", \"name\". Defaults to \"name\".",
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ParseImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importID string
+	var fields []string
+
+	resp.Error = req.Arguments.Get(ctx, &importID, &fields)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(fields) == 0 {
+		fields = []string{"name"}
+	}
+
+	meta := common.ImportMetadata{
+		ResourceName:   "<type>",
+		RequiredFields: fields,
+		AllowedOptions: importIDOptionKeys(importID),
+	}
+
+	result, diagnostic := meta.ParseImportID(importID)
+	if diagnostic != nil {
+		resp.Error = function.FuncErrorFromDiags(ctx, diag.Diagnostics{diagnostic})
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// importIDOptionKeys returns the keys of the options in the given import ID,
+// as the allowed options depend on the resource, which is not known here.
+func importIDOptionKeys(importID string) []string {
+	parts := strings.Split(importID, ",")
+
+	keys := make([]string, 0, len(parts)-1)
+	for _, option := range parts[1:] {
+		key, _, _ := strings.Cut(option, "=")
+		keys = append(keys, key)
+	}
+
+	return keys
+}
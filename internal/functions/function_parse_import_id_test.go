@@ -0,0 +1,91 @@
+package functions_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccParseImportIDFunction_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParseImportIDFunction_basic(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("name", "c1"),
+					resource.TestCheckOutput("remote", "local"),
+					resource.TestCheckOutput("project", "proj"),
+					resource.TestCheckOutput("image", "alpine"),
+					resource.TestCheckOutput("pool", "default"),
+					resource.TestCheckOutput("volume", "vol"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccParseImportIDFunction_missingFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccParseImportIDFunction_missingFields(),
+				ExpectError: regexp.MustCompile(`Import ID does not contain all required fields`),
+			},
+		},
+	})
+}
+
+func testAccParseImportIDFunction_basic() string {
+	return `
+locals {
+  instance = provider::incus::parse_import_id("local:proj/c1,image=alpine")
+  volume   = provider::incus::parse_import_id("/default/custom/vol", "pool", "type", "name")
+}
+
+output "name" {
+  value = local.instance.name
+}
+
+output "remote" {
+  value = local.instance.remote
+}
+
+output "project" {
+  value = local.instance.project
+}
+
+output "image" {
+  value = local.instance.image
+}
+
+output "pool" {
+  value = local.volume.pool
+}
+
+output "volume" {
+  value = local.volume.name
+}
+`
+}
+
+func testAccParseImportIDFunction_missingFields() string {
+	return `
+output "invalid" {
+  value = provider::incus::parse_import_id("default/vol", "pool", "name")
+}
+`
+}
@@ -0,0 +1,152 @@
+package image
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// ImageListModel contains the filters of the image list resource.
+type ImageListModel struct {
+	Project    types.String `tfsdk:"project"`
+	Remote     types.String `tfsdk:"remote"`
+	Type       types.String `tfsdk:"type"`
+	Properties types.Map    `tfsdk:"properties"`
+}
+
+// ImageListResource lists Incus cached images.
+type ImageListResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewImageListResource returns a new image list resource.
+func NewImageListResource() list.ListResource {
+	return &ImageListResource{}
+}
+
+func (r ImageListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_image", req.ProviderTypeName)
+}
+
+func (r ImageListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := common.ListSchemaAttributes()
+
+	attributes["type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list images of the given type.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("container", "virtual-machine"),
+		},
+	}
+
+	attributes["properties"] = schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Only list images whose properties contain all the given key/value pairs.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (r *ImageListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r ImageListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter ImageListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	propertiesFilter, diags := common.ToConfigMap(ctx, filter.Properties)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	remote := filter.Remote.ValueString()
+	project := filter.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	images, err := server.GetImages()
+	if err != nil {
+		diags.AddError("Failed to retrieve images", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	imageResource := ImageResource{provider: r.provider}
+	syncState := func(ctx context.Context, state *tfsdk.State, m ImageModel) diag.Diagnostics {
+		return imageResource.SyncState(ctx, state, server, m)
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, image := range images {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			if !common.MatchFilter(filter.Type, image.Type) || !common.MatchConfig(image.Properties, propertiesFilter) {
+				continue
+			}
+
+			fields := map[string]string{
+				"fingerprint": image.Fingerprint,
+				"resource_id": createImageResourceID(remote, image.Fingerprint),
+				"project":     project,
+				"remote":      remote,
+			}
+
+			// Prefer the first alias over the fingerprint, which is
+			// hardly readable.
+			displayName := image.Fingerprint
+			if len(image.Aliases) > 0 {
+				displayName = image.Aliases[0].Name
+			}
+
+			result, ok := common.NewListResult(ctx, req, displayName, fields, syncState)
+			if !ok {
+				continue
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
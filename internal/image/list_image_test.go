@@ -0,0 +1,50 @@
+package image_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccImageList_properties(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImage_basic(),
+			},
+			{
+				Query:  true,
+				Config: testAccImageList_properties(),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLengthAtLeast("incus_image.alpine", 1),
+				},
+			},
+		},
+	})
+}
+
+func testAccImageList_properties() string {
+	return `
+provider "incus" {}
+
+list "incus_image" "alpine" {
+  provider = incus
+
+  config {
+    type = "container"
+    properties = {
+      "os" = "Alpine"
+    }
+  }
+}
+`
+}
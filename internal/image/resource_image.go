@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -43,6 +44,8 @@ type ImageModel struct {
 	Project        types.String `tfsdk:"project"`
 	Remote         types.String `tfsdk:"remote"`
 
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
 	// Computed.
 	ResourceID    types.String `tfsdk:"resource_id"`
 	CreatedAt     types.Int64  `tfsdk:"created_at"`
@@ -87,7 +90,11 @@ func (r ImageResource) Metadata(_ context.Context, req resource.MetadataRequest,
 	resp.TypeName = fmt.Sprintf("%s_image", req.ProviderTypeName)
 }
 
-func (r ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r ImageResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = common.IdentitySchema("fingerprint")
+}
+
+func (r ImageResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"source_file": schema.SingleNestedAttribute{
@@ -219,6 +226,8 @@ func (r ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 		},
 
 		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+
 			"alias": schema.SetNestedBlock{
 				Description: "Image alias",
 				NestedObject: schema.NestedBlockObject{
@@ -289,16 +298,24 @@ func (r ImageResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	if !plan.SourceFile.IsNull() {
 		r.createImageFromSourceFile(ctx, resp, &plan)
-		return
 	} else if !plan.SourceImage.IsNull() {
 		r.createImageFromSourceImage(ctx, resp, &plan)
-		return
 	} else if !plan.SourceInstance.IsNull() {
 		r.createImageFromSourceInstance(ctx, resp, &plan)
-		return
 	}
+
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -311,6 +328,15 @@ func (r ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -322,6 +348,11 @@ func (r ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -333,6 +364,15 @@ func (r ImageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -393,6 +433,7 @@ func (r ImageResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -404,6 +445,15 @@ func (r ImageResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -420,13 +470,34 @@ func (r ImageResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err = opDelete.Wait()
+	err = opDelete.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove cached image with fingerprint %q", imageFingerprint), err.Error())
 		return
 	}
 }
 
+func (r ImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "image",
+		RequiredFields: []string{"fingerprint"},
+	}
+
+	fields, diags := meta.ParseImportRequest(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+
+	// The image is looked up using its resource ID.
+	resourceID := createImageResourceID(fields["remote"], fields["fingerprint"])
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_id"), resourceID)...)
+}
+
 // SyncState fetches the server's current state for a cached image and
 // updates the provided model. It then applies this updated model as the
 // new state in Terraform.
@@ -615,7 +686,7 @@ func (r ImageResource) createImageFromSourceFile(ctx context.Context, resp *reso
 	}
 
 	// Wait for image create operation to finish.
-	err = op.Wait()
+	err = op.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create image from file %q", dataPath), err.Error())
 		return
@@ -746,7 +817,7 @@ func (r ImageResource) createImageFromSourceImage(ctx context.Context, resp *res
 	}
 
 	// Wait for copy operation to finish.
-	err = opCopy.Wait()
+	err = opCopy.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to copy image %q", image), err.Error())
 		return
@@ -861,7 +932,7 @@ func (r ImageResource) createImageFromSourceInstance(ctx context.Context, resp *
 	}
 
 	// Wait for create operation to finish.
-	err = op.Wait()
+	err = op.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to publish instance %q image", instanceName), err.Error())
 		return
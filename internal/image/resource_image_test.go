@@ -8,6 +8,7 @@ import (
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 
 	"github.com/lxc/terraform-provider-incus/internal/acctest"
 )
@@ -49,6 +50,30 @@ func TestAccImage_basicVM(t *testing.T) {
 	})
 }
 
+func TestAccImage_import(t *testing.T) {
+	resourceName := "incus_image.img1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImage_noAliases(),
+			},
+			{
+				ResourceName: resourceName,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.Attributes["fingerprint"], nil
+				},
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "fingerprint",
+				ImportStateVerifyIgnore:              []string{"source_image", "copied_aliases"},
+			},
+		},
+	})
+}
+
 func TestAccImage_alias(t *testing.T) {
 	alias1 := petname.Generate(2, "-")
 	alias2 := petname.Generate(2, "-")
@@ -466,6 +491,17 @@ resource "incus_image" "img1" {
 	`
 }
 
+func testAccImage_noAliases() string {
+	return `
+resource "incus_image" "img1" {
+  source_image = {
+    remote = "images"
+    name   = "alpine/edge"
+  }
+}
+	`
+}
+
 func testAccImage_basicVM() string {
 	return `
 resource "incus_image" "img1vm" {
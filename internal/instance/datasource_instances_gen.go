@@ -0,0 +1,209 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type InstancesDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+
+	// Filters.
+	Filters types.List   `tfsdk:"filters"`
+	Config  types.Map    `tfsdk:"config"`
+	Status  types.String `tfsdk:"status"`
+
+	Instances types.Map `tfsdk:"instances"`
+}
+
+type InstancesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewInstancesDataSource() datasource.DataSource {
+	return &InstancesDataSource{}
+}
+
+func (d *InstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instances", req.ProviderTypeName)
+}
+
+func (d *InstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"config": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"status": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"instances": schema.MapAttribute{
+				Computed:    true,
+				ElementType: getInstancesElemType(),
+			},
+		},
+	}
+}
+
+func (d *InstancesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *InstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state InstancesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := ""
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	var filters []string
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, state.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instances, err := server.GetInstancesWithFilter(api.InstanceTypeAny, filters)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve instances", err.Error())
+		return
+	}
+
+	elemType := getInstancesElemType()
+	elems := make(map[string]attr.Value, len(instances))
+
+	for _, instance := range instances {
+		if !common.MatchConfig(instance.Config, configFilter) {
+			continue
+		}
+
+		if !common.MatchFilter(state.Status, instance.Status) {
+			continue
+		}
+
+		attrs := map[string]attr.Value{
+			"name":         types.StringValue(instance.Name),
+			"description":  types.StringValue(instance.Description),
+			"status":       types.StringValue(instance.Status),
+			"location":     types.StringValue(instance.Location),
+			"type":         types.StringValue(instance.Type),
+			"architecture": types.StringValue(instance.Architecture),
+			"ephemeral":    types.BoolValue(instance.Ephemeral),
+			"stateful":     types.BoolValue(instance.Stateful),
+		}
+
+		attrs["config"], diags = common.ToConfigMapType(ctx, common.ToNullableConfig(instance.Config), types.MapNull(types.StringType))
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		attrs["device"], diags = common.ToDeviceSetType(ctx, instance.Devices)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		attrs["profiles"], diags = toInstanceProfilesListTypeValue(ctx, instance.Profiles)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		key := instance.Name
+		elems[key], diags = types.ObjectValue(elemType.AttrTypes, attrs)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	state.Instances, diags = types.MapValue(elemType, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getInstancesElemType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":         types.StringType,
+			"description":  types.StringType,
+			"config":       types.MapType{ElemType: types.StringType},
+			"status":       types.StringType,
+			"location":     types.StringType,
+			"device":       common.DeviceSetType(),
+			"type":         types.StringType,
+			"architecture": types.StringType,
+			"ephemeral":    types.BoolType,
+			"profiles":     getInstanceProfilesListType(),
+			"stateful":     types.BoolType,
+		},
+	}
+}
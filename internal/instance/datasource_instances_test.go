@@ -0,0 +1,94 @@
+package instance_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstancesDataSource_filters(t *testing.T) {
+	instanceName1 := petname.Generate(2, "-")
+	instanceName2 := petname.Generate(2, "-")
+	team := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstancesDataSource_filters(instanceName1, instanceName2, team, acctest.TestImage),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.incus_instances.team", "instances.%", "2"),
+					resource.TestCheckResourceAttr("data.incus_instances.team", fmt.Sprintf("instances.%s.name", instanceName1), instanceName1),
+					resource.TestCheckResourceAttr("data.incus_instances.team", fmt.Sprintf("instances.%s.status", instanceName1), "Running"),
+					resource.TestCheckResourceAttr("data.incus_instances.team", fmt.Sprintf("instances.%s.config.user.team", instanceName1), team),
+					resource.TestCheckResourceAttr("data.incus_instances.team", fmt.Sprintf("instances.%s.status", instanceName2), "Stopped"),
+					resource.TestCheckResourceAttr("data.incus_instances.stopped", "instances.%", "1"),
+					resource.TestCheckResourceAttr("data.incus_instances.stopped", fmt.Sprintf("instances.%s.name", instanceName2), instanceName2),
+					resource.TestCheckResourceAttr("data.incus_instances.by_name", "instances.%", "1"),
+					resource.TestCheckResourceAttr("data.incus_instances.by_name", fmt.Sprintf("instances.%s.name", instanceName1), instanceName1),
+				),
+			},
+		},
+	})
+}
+
+func testAccInstancesDataSource_filters(instanceName1, instanceName2, team, image string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%[1]s"
+  image = "%[4]s"
+
+  config = {
+    "user.team" = "%[3]s"
+  }
+}
+
+resource "incus_instance" "instance2" {
+  name    = "%[2]s"
+  image   = "%[4]s"
+  running = false
+
+  config = {
+    "user.team" = "%[3]s"
+  }
+}
+
+data "incus_instances" "team" {
+  config = {
+    "user.team" = "%[3]s"
+  }
+
+  depends_on = [
+    incus_instance.instance1,
+    incus_instance.instance2,
+  ]
+}
+
+data "incus_instances" "stopped" {
+  status = "stopped"
+
+  config = {
+    "user.team" = "%[3]s"
+  }
+
+  depends_on = [
+    incus_instance.instance1,
+    incus_instance.instance2,
+  ]
+}
+
+data "incus_instances" "by_name" {
+  filters = ["name=%[1]s"]
+
+  depends_on = [
+    incus_instance.instance1,
+    incus_instance.instance2,
+  ]
+}
+`, instanceName1, instanceName2, team, image)
+}
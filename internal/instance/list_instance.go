@@ -0,0 +1,151 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// InstanceListModel contains the filters of the instance list resource.
+type InstanceListModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+	Type    types.String `tfsdk:"type"`
+	Status  types.String `tfsdk:"status"`
+	Config  types.Map    `tfsdk:"config"`
+}
+
+// InstanceListResource lists Incus instances.
+type InstanceListResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewInstanceListResource returns a new instance list resource.
+func NewInstanceListResource() list.ListResource {
+	return &InstanceListResource{}
+}
+
+func (r InstanceListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instance", req.ProviderTypeName)
+}
+
+func (r InstanceListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := common.ListSchemaAttributes()
+
+	attributes["type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list instances of the given type.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("container", "virtual-machine"),
+		},
+	}
+
+	attributes["status"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list instances with the given status, such as Running or Stopped.",
+	}
+
+	attributes["config"] = schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Only list instances whose configuration contains all the given key/value pairs.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (r *InstanceListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r InstanceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter InstanceListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, filter.Config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	remote := filter.Remote.ValueString()
+	project := filter.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	instances, err := server.GetInstances(api.InstanceType(filter.Type.ValueString()))
+	if err != nil {
+		diags.AddError("Failed to retrieve instances", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	instanceResource := InstanceResource{provider: r.provider}
+	syncState := func(ctx context.Context, state *tfsdk.State, m InstanceModel) diag.Diagnostics {
+		return instanceResource.SyncState(ctx, state, server, m)
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, instance := range instances {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			if !common.MatchFilter(filter.Status, instance.Status) || !common.MatchConfig(instance.Config, configFilter) {
+				continue
+			}
+
+			fields := map[string]string{
+				"name":    instance.Name,
+				"project": project,
+				"remote":  remote,
+			}
+
+			result, ok := common.NewListResult(ctx, req, instance.Name, fields, syncState)
+			if !ok {
+				continue
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package instance_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstanceList_filters(t *testing.T) {
+	instanceName1 := petname.Generate(2, "-")
+	instanceName2 := petname.Generate(2, "-")
+	team := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceList_resources(instanceName1, instanceName2, team, acctest.TestImage),
+			},
+			{
+				Query:  true,
+				Config: testAccInstanceList_filters(team),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("incus_instance.running", 1),
+					querycheck.ExpectIdentity("incus_instance.running", map[string]knownvalue.Check{
+						"name":    knownvalue.StringExact(instanceName1),
+						"project": knownvalue.Null(),
+						"remote":  knownvalue.Null(),
+					}),
+					querycheck.ExpectLength("incus_instance.stopped", 1),
+					querycheck.ExpectIdentity("incus_instance.stopped", map[string]knownvalue.Check{
+						"name":    knownvalue.StringExact(instanceName2),
+						"project": knownvalue.Null(),
+						"remote":  knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func testAccInstanceList_resources(instanceName1, instanceName2, team, image string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%[1]s"
+  image = "%[4]s"
+
+  config = {
+    "user.team" = "%[3]s"
+  }
+}
+
+resource "incus_instance" "instance2" {
+  name    = "%[2]s"
+  image   = "%[4]s"
+  running = false
+
+  config = {
+    "user.team" = "%[3]s"
+  }
+}
+`, instanceName1, instanceName2, team, image)
+}
+
+func testAccInstanceList_filters(team string) string {
+	return fmt.Sprintf(`
+provider "incus" {}
+
+list "incus_instance" "running" {
+  provider = incus
+
+  config {
+    type   = "container"
+    status = "running"
+    config = {
+      "user.team" = "%[1]s"
+    }
+  }
+}
+
+list "incus_instance" "stopped" {
+  provider = incus
+
+  config {
+    status = "Stopped"
+    config = {
+      "user.team" = "%[1]s"
+    }
+  }
+}
+`, team)
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
@@ -23,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -31,6 +34,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/shared/api"
+	"github.com/lxc/incus/v7/shared/util"
 	"github.com/mitchellh/go-homedir"
 
 	"github.com/lxc/terraform-provider-incus/internal/common"
@@ -59,11 +63,18 @@ type InstanceModel struct {
 	SourceFile     types.String `tfsdk:"source_file"`
 	Architecture   types.String `tfsdk:"architecture"`
 
+	MoveStrategy        types.String `tfsdk:"move_strategy"`
+	RestoreFromSnapshot types.String `tfsdk:"restore_from_snapshot"`
+	RestoreStateful     types.Bool   `tfsdk:"restore_stateful"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
 	// Computed.
 	IPv4       types.String `tfsdk:"ipv4_address"`
 	IPv6       types.String `tfsdk:"ipv6_address"`
 	MAC        types.String `tfsdk:"mac_address"`
 	Status     types.String `tfsdk:"status"`
+	Location   types.String `tfsdk:"location"`
 	Interfaces types.Map    `tfsdk:"interfaces"`
 }
 
@@ -123,9 +134,16 @@ func NewInstanceResource() resource.Resource {
 
 func (r InstanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = fmt.Sprintf("%s_instance", req.ProviderTypeName)
+
+	// Instances can be renamed and moved between projects and remotes.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
-func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r InstanceResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = common.IdentitySchema("name")
+}
+
+func (r InstanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -224,7 +242,7 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"project": schema.StringAttribute{
 				Optional: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceUnlessMoved(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
@@ -234,15 +252,27 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 			"remote": schema.StringAttribute{
 				Optional: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					requiresReplaceUnlessMoved(),
 				},
 			},
 
+			// Determines whether a change of project or remote replaces
+			// the instance or moves the existing one.
+			"move_strategy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("replace"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("replace", "move"),
+				},
+			},
+
+			// Changing the target migrates the instance.
 			"target": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
@@ -299,6 +329,45 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 								stringvalidator.OneOf("on_change", "once"),
 							},
 						},
+						"phase": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									common.ExecPhaseOnCreate,
+									common.ExecPhaseOnStart,
+									common.ExecPhaseBeforeStop,
+									common.ExecPhaseBeforeDestroy,
+									common.ExecPhaseAfterUpdate,
+								),
+							},
+						},
+						"on_failure": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(common.ExecOnFailureFail, common.ExecOnFailureContinue),
+							},
+						},
+						"capture_output": schema.BoolAttribute{
+							Optional: true,
+						},
+						"max_output_size": schema.Int64Attribute{
+							Optional: true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"stdout": schema.StringAttribute{
+							Computed: true,
+						},
+						"stderr": schema.StringAttribute{
+							Computed: true,
+						},
+						"exit_code": schema.Int64Attribute{
+							Computed: true,
+						},
+						"last_run_at": schema.StringAttribute{
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -351,6 +420,22 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 
+			"restore_from_snapshot": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"restore_stateful": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("restore_from_snapshot")),
+				},
+			},
+
 			// Computed.
 
 			"ipv4_address": schema.StringAttribute{
@@ -369,6 +454,10 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed: true,
 			},
 
+			"location": schema.StringAttribute{
+				Computed: true,
+			},
+
 			"interfaces": schema.MapNestedAttribute{
 				Computed:    true,
 				Description: "Map of the instance network interfaces",
@@ -417,6 +506,8 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 		},
 
 		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+
 			"wait_for": schema.SetNestedBlock{
 				Description: "Wait for instance to be ready",
 				NestedObject: schema.NestedBlockObject{
@@ -516,6 +607,36 @@ func (r InstanceResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							Computed: true,
 							Default:  booldefault.StaticBool(false),
 						},
+
+						"track_drift": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"template": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"recursive": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"include": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+
+						"exclude": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+
+						"delete_extraneous": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"source_hash": schema.StringAttribute{
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -552,6 +673,50 @@ func (r *InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 	if profiles.IsNull() {
 		resp.Plan.SetAttribute(ctx, path.Root("profiles"), []string{"default"})
 	}
+
+	// Compute the hashes of the directories uploaded recursively.
+	var files types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file"), &files)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, diags := common.PlanFileSourceHashes(ctx, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file"), files)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Mark the outputs of the exec commands, which are run during apply,
+	// as unknown and keep the outputs of all others.
+	var planExec types.Map
+	var stateExec types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("exec"), &planExec)...)
+
+	isCreate := req.State.Raw.IsNull()
+	if !isCreate {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("exec"), &stateExec)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Commands of lifecycle phases may run on any update.
+	isUpdate := !isCreate && !resp.Plan.Raw.Equal(req.State.Raw)
+
+	planExec, diags = planExecOutputs(ctx, planExec, stateExec, isCreate, isUpdate)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("exec"), planExec)...)
 }
 
 func (r InstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
@@ -587,6 +752,15 @@ func (r InstanceResource) ValidateConfig(ctx context.Context, req resource.Valid
 		)
 	}
 
+	// Ephemeral instance cannot be moved, as it is stopped beforehand.
+	if ephemeral && config.MoveStrategy.ValueString() == "move" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("move_strategy"),
+			fmt.Sprintf("Instance %q is ephemeral and cannot be moved", config.Name.ValueString()),
+			fmt.Sprintf("Ephemeral instances are removed when stopped, therefore attribute %q cannot be set to %q.", "move_strategy", "move"),
+		)
+	}
+
 	if !config.SourceFile.IsNull() {
 		// With `incus import`, a storage pool can be provided optionally.
 		// In order to support the same behavior with source_file,
@@ -652,6 +826,8 @@ func (r InstanceResource) ValidateConfig(ctx context.Context, req resource.Valid
 			if config.IsVirtualMachine() {
 				validateWaitForAgentWithFiles(ctx, config, resp)
 			}
+
+			validateFileTemplates(ctx, config, resp)
 		}
 	}
 
@@ -727,14 +903,16 @@ func validateExec(ctx context.Context, config InstanceModel, resp *resource.Vali
 	}
 
 	if len(execMap) > 0 {
-		if !config.Running.IsNull() && !config.Running.ValueBool() {
-			resp.Diagnostics.AddError(
-				"Invalid Configuration",
-				"Exec commands can only be run on running instances.",
-			)
-		}
-
 		for name, exec := range execMap {
+			// Commands of lifecycle phases only run while the instance
+			// is running, e.g. before it is stopped.
+			if exec.Phase.IsNull() && !config.Running.IsNull() && !config.Running.ValueBool() {
+				resp.Diagnostics.AddError(
+					"Invalid Configuration",
+					fmt.Sprintf("Exec entry %q can only be run on running instances, unless it has a %q.", name, "phase"),
+				)
+			}
+
 			var command []string
 			diags = exec.Command.ElementsAs(ctx, &command, false)
 			if diags.HasError() {
@@ -748,6 +926,32 @@ func validateExec(ctx context.Context, config InstanceModel, resp *resource.Vali
 					fmt.Sprintf("Exec entry %q must include a non-empty command.", name),
 				)
 			}
+
+			if !exec.Trigger.IsNull() && !exec.Phase.IsNull() {
+				resp.Diagnostics.AddError(
+					"Invalid Configuration",
+					fmt.Sprintf("Exec entry %q cannot set both %q and %q.", name, "trigger", "phase"),
+				)
+			}
+		}
+	}
+}
+
+// validateFileTemplates validates the templated file configurations.
+func validateFileTemplates(ctx context.Context, config InstanceModel, resp *resource.ValidateConfigResponse) {
+	files, diags := common.ToFileMap(ctx, config.Files)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	for targetPath, file := range files {
+		err := common.ValidateFileTemplate(file)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Configuration",
+				fmt.Sprintf("Invalid template for file %q: %v", targetPath, err),
+			)
 		}
 	}
 }
@@ -779,6 +983,15 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	target := plan.Target.ValueString()
@@ -813,6 +1026,21 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 
 	instanceName := plan.Name.ValueString()
 
+	// The outputs of the exec commands are only known once they have run.
+	plan.Exec, diags = common.SetExecResults(ctx, plan.Exec, nil, nil)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	execs, diags := common.ToExecMap(ctx, plan.Exec)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	execResults := make(map[string]common.InstanceExecResult)
+
 	// Update Terraform state early to ensure the instance can still be
 	// reconciled or destroyed if subsequent wait operations fail.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
@@ -821,6 +1049,8 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
+
 	// We must ensure that the instance is running before we can upload files.
 	if plan.Running.ValueBool() || (!plan.Files.IsNull() && !plan.Files.IsUnknown()) {
 		diag := startInstance(ctx, server, instanceName)
@@ -837,6 +1067,12 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 				return
 			}
 		}
+
+		diags = runPhaseExecs(ctx, server, instanceName, plan.IsVirtualMachine(), execs, common.ExecPhaseOnStart, execResults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Upload files.
@@ -847,8 +1083,25 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 			return
 		}
 
+		// Templates are rendered once the instance is running, such that
+		// computed attributes like the IP address are available.
+		var templateData map[string]any
+		if common.HasFileTemplate(files) {
+			templateData, diags = instanceFileTemplateData(ctx, server, instanceName)
+			if diags.HasError() {
+				resp.Diagnostics.Append(diags...)
+				return
+			}
+		}
+
 		for _, f := range files {
-			err := common.InstanceFileUpload(server, instanceName, f)
+			f, err := common.RenderFileTemplate(f, templateData)
+			if err != nil {
+				resp.Diagnostics.AddError(fmt.Sprintf("Failed to render file %q for instance %q", f.TargetPath.ValueString(), instanceName), err.Error())
+				return
+			}
+
+			err = common.InstanceFileUpload(server, instanceName, f)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to instance %q", instanceName), err.Error())
 				return
@@ -857,41 +1110,24 @@ func (r InstanceResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Run exec commands.
-	if !plan.Exec.IsNull() && !plan.Exec.IsUnknown() {
-		execPlan, diags := common.ToExecMap(ctx, plan.Exec)
-		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
+	diags = runTriggeredExecs(ctx, server, instanceName, plan.IsVirtualMachine(), execs, nil, true, execResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Running.ValueBool() {
+		diags = runPhaseExecs(ctx, server, instanceName, plan.IsVirtualMachine(), execs, common.ExecPhaseOnCreate, execResults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		if len(execPlan) > 0 {
-			runs, diags := collectExecRuns(ctx, execPlan, nil, true)
-			if diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-
-			if len(runs) > 0 {
-				if plan.IsVirtualMachine() {
-					diags := waitForInstanceAgent(ctx, server, instanceName)
-					if diags != nil {
-						resp.Diagnostics.Append(diags...)
-						return
-					}
-				}
-
-				for _, run := range runs {
-					stdout, stderr, err := common.RunInstanceExec(ctx, server, instanceName, run.ExecConfig)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							fmt.Sprintf("Failed to execute command %q in instance %q", run.Name, instanceName),
-							formatExecError(err, stdout, stderr),
-						)
-						return
-					}
-				}
-			}
-		}
+	plan.Exec, diags = common.SetExecResults(ctx, plan.Exec, nil, execResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Update Terraform state.
@@ -908,6 +1144,15 @@ func (r InstanceResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	target := state.Target.ValueString()
@@ -920,6 +1165,30 @@ func (r InstanceResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Files can only be retrieved from running instances, which is also
+	// required to upload them.
+	var running types.Bool
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("running"), &running)...)
+	if resp.Diagnostics.HasError() || !running.ValueBool() {
+		return
+	}
+
+	files, diags := common.SyncInstanceFiles(ctx, server, state.Name.ValueString(), state.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("file"), files)...)
 }
 
 // Update updates the instance in the following order:
@@ -943,6 +1212,15 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	target := plan.Target.ValueString()
@@ -953,18 +1231,111 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	instanceName := state.Name.ValueString()
+
+	execs, diags := common.ToExecMap(ctx, plan.Exec)
+	resp.Diagnostics.Append(diags...)
+
+	stateExecs, diags := common.ToExecMap(ctx, state.Exec)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	execResults := make(map[string]common.InstanceExecResult)
+
+	// Move the instance to another project or remote.
+	moved := plan.Project.ValueString() != state.Project.ValueString() || plan.Remote.ValueString() != state.Remote.ValueString()
+	if moved {
+		diags := r.moveInstance(ctx, server, plan, state, execs, execResults)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		// Track the instance in its new location, even if any of
+		// the following steps fail.
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), plan.Project)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote"), plan.Remote)...)
+	}
+
 	instanceState, _, err := server.GetInstanceState(instanceName)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
 		return
 	}
 
+	// Restore the instance from a snapshot when a new snapshot is requested.
+	restoreFromSnapshot := plan.RestoreFromSnapshot.ValueString()
+	if restoreFromSnapshot != "" && restoreFromSnapshot != state.RestoreFromSnapshot.ValueString() {
+		diags := restoreInstance(ctx, server, instanceName, restoreFromSnapshot, plan.RestoreStateful.ValueBool(), plan.IsVirtualMachine(), execs, execResults)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		// The restore may have changed the running state of the instance.
+		instanceState, _, err = server.GetInstanceState(instanceName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+			return
+		}
+	}
+
+	// Migrate the instance if either the target cluster member or the
+	// storage pool of the root disk has changed.
+	planTarget := plan.Target.ValueString()
+	targetChanged := !moved && !plan.Target.IsUnknown() && planTarget != state.Target.ValueString()
+
+	planPool, diags := rootDiskPool(ctx, plan.Devices)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	poolChanged := false
+	if planPool != "" {
+		// Compare against the expanded devices, as the root disk may be
+		// inherited from a profile rather than defined on the instance.
+		instance, _, err := server.GetInstance(instanceName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve instance %q", instanceName), err.Error())
+			return
+		}
+
+		poolChanged = planPool != rootDiskPoolFromDevices(instance.ExpandedDevices)
+	}
+
+	if targetChanged || poolChanged {
+		migrateTarget := ""
+		if targetChanged {
+			migrateTarget = planTarget
+		}
+
+		migratePool := ""
+		if poolChanged {
+			migratePool = planPool
+		}
+
+		diags := migrateInstance(ctx, server, instanceName, migrateTarget, migratePool, execs, execResults)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		// Cold migration leaves the instance stopped.
+		instanceState, _, err = server.GetInstanceState(instanceName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+			return
+		}
+	}
+
 	// Stop before applying configuration changes if the desired state is stopped.
 	if !plan.Running.ValueBool() && !isInstanceStopped(*instanceState) {
-		// Stop the instance gracefully.
-		_, diag := stopInstance(ctx, server, instanceName, false)
-		if diag != nil {
-			resp.Diagnostics.Append(diag)
+		diags := stopInstanceGracefully(ctx, server, instanceName, plan.IsVirtualMachine(), execs, execResults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
@@ -1008,7 +1379,7 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 	opUpdate, err := server.UpdateInstance(instanceName, newInstance, etag)
 	if err == nil {
 		// Wait for the instance to be updated.
-		err = opUpdate.Wait()
+		err = opUpdate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1034,6 +1405,12 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 				return
 			}
 		}
+
+		diags := runPhaseExecs(ctx, server, instanceName, plan.IsVirtualMachine(), execs, common.ExecPhaseOnStart, execResults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	oldFiles, diags := common.ToFileMap(ctx, state.Files)
@@ -1053,20 +1430,40 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 			continue
 		}
 
-		targetPath := f.TargetPath.ValueString()
-		err := common.InstanceFileDelete(server, instanceName, targetPath)
+		var err error
+		if f.Recursive.ValueBool() {
+			err = common.InstanceDirectoryDelete(server, instanceName, f)
+		} else {
+			err = common.InstanceFileDelete(server, instanceName, f.TargetPath.ValueString())
+		}
+
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from instance %q", instanceName), err.Error())
 			return
 		}
 	}
 
+	var templateData map[string]any
+	if common.HasFileTemplate(newFiles) {
+		templateData, diags = instanceFileTemplateData(ctx, server, instanceName)
+		if diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
 	// Upload new files or update existing files if content has changed.
 	for k, newFile := range newFiles {
 		oldFile, exists := oldFiles[k]
 
+		uploadFile, err := common.RenderFileTemplate(newFile, templateData)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to render file %q for instance %q", newFile.TargetPath.ValueString(), instanceName), err.Error())
+			return
+		}
+
 		if !exists {
-			err := common.InstanceFileUpload(server, instanceName, newFile)
+			err := common.InstanceFileUpload(server, instanceName, uploadFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload file to instance %q", instanceName), err.Error())
 				return
@@ -1079,15 +1476,17 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		if contentChanged || permissionsChanged {
 			// Delete the old file first otherwise mode and ownership changes
-			// will not be applied.
-			targetPath := newFile.TargetPath.ValueString()
-			err := common.InstanceFileDelete(server, instanceName, targetPath)
-			if err != nil {
-				resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from instance %q", instanceName), err.Error())
-				return
+			// will not be applied. Directories replace each file separately.
+			if !newFile.Recursive.ValueBool() {
+				targetPath := newFile.TargetPath.ValueString()
+				err := common.InstanceFileDelete(server, instanceName, targetPath)
+				if err != nil {
+					resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from instance %q", instanceName), err.Error())
+					return
+				}
 			}
 
-			err = common.InstanceFileUpload(server, instanceName, newFile)
+			err := common.InstanceFileUpload(server, instanceName, uploadFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to instance %q", instanceName), err.Error())
 				return
@@ -1096,47 +1495,10 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Run exec commands.
-	if !plan.Exec.IsNull() && !plan.Exec.IsUnknown() {
-		execPlan, diags := common.ToExecMap(ctx, plan.Exec)
-		if diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
-		}
-
-		if len(execPlan) > 0 {
-			execState, diags := common.ToExecMap(ctx, state.Exec)
-			if diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-
-			runs, diags := collectExecRuns(ctx, execPlan, execState, false)
-			if diags.HasError() {
-				resp.Diagnostics.Append(diags...)
-				return
-			}
-
-			if len(runs) > 0 {
-				if plan.IsVirtualMachine() {
-					diags := waitForInstanceAgent(ctx, server, instanceName)
-					if diags != nil {
-						resp.Diagnostics.Append(diags...)
-						return
-					}
-				}
-
-				for _, run := range runs {
-					stdout, stderr, err := common.RunInstanceExec(ctx, server, instanceName, run.ExecConfig)
-					if err != nil {
-						resp.Diagnostics.AddError(
-							fmt.Sprintf("Failed to execute command %q in instance %q", run.Name, instanceName),
-							formatExecError(err, stdout, stderr),
-						)
-						return
-					}
-				}
-			}
-		}
+	diags = runTriggeredExecs(ctx, server, instanceName, plan.IsVirtualMachine(), execs, stateExecs, false, execResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Rename instance
@@ -1155,10 +1517,9 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 
 		// Stop instance if it's running (required for rename operation)
 		if !isInstanceStopped(*instanceState) {
-			// Stop the instance gracefully.
-			_, diag := stopInstance(ctx, server, instanceName, false)
-			if diag != nil {
-				resp.Diagnostics.Append(diag)
+			diags := stopInstanceGracefully(ctx, server, instanceName, plan.IsVirtualMachine(), execs, execResults)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
 				return
 			}
 		}
@@ -1166,7 +1527,7 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 		opUpdate, err = server.RenameInstance(instanceName, renameInstance)
 		if err == nil {
 			// Wait for the instance to be updated.
-			err = opUpdate.Wait()
+			err = opUpdate.WaitContext(ctx)
 		}
 
 		if err != nil {
@@ -1181,12 +1542,34 @@ func (r InstanceResource) Update(ctx context.Context, req resource.UpdateRequest
 				resp.Diagnostics.Append(diag)
 				return
 			}
+
+			diags := runPhaseExecs(ctx, server, newInstanceName, plan.IsVirtualMachine(), execs, common.ExecPhaseOnStart, execResults)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
 		}
 	}
 
+	if plan.Running.ValueBool() {
+		diags = runPhaseExecs(ctx, server, newInstanceName, plan.IsVirtualMachine(), execs, common.ExecPhaseAfterUpdate, execResults)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Entries which were not run keep their previous outputs.
+	plan.Exec, diags = common.SetExecResults(ctx, plan.Exec, stateExecs, execResults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 type execRun struct {
@@ -1224,6 +1607,127 @@ func collectExecRuns(ctx context.Context, planExec map[string]common.InstanceExe
 	return orderedRuns, nil
 }
 
+// planExecOutputs sets the computed outputs of the exec entries, which are
+// run during apply, to unknown. All other entries keep their outputs from
+// the state.
+func planExecOutputs(ctx context.Context, planExec types.Map, stateExec types.Map, isCreate bool, isUpdate bool) (types.Map, diag.Diagnostics) {
+	if planExec.IsNull() || planExec.IsUnknown() {
+		return planExec, nil
+	}
+
+	planExecs, diags := common.ToExecMap(ctx, planExec)
+	if diags.HasError() {
+		return planExec, diags
+	}
+
+	stateExecs, diags := common.ToExecMap(ctx, stateExec)
+	if diags.HasError() {
+		return planExec, diags
+	}
+
+	// Entries, which are not fully known yet, may run during apply.
+	willRun := make(map[string]bool, len(planExecs))
+	knownExecs := make(map[string]common.InstanceExecModel, len(planExecs))
+	for name, exec := range planExecs {
+		if hasUnknownExecConfig(ctx, exec) {
+			willRun[name] = true
+			continue
+		}
+
+		knownExecs[name] = exec
+	}
+
+	runs, diags := collectExecRuns(ctx, knownExecs, stateExecs, isCreate)
+	if diags.HasError() {
+		return planExec, diags
+	}
+
+	for _, run := range runs {
+		willRun[run.Name] = true
+	}
+
+	// Whether the commands of the start and stop phases run is only known
+	// during apply.
+	for name, exec := range knownExecs {
+		switch exec.Phase.ValueString() {
+		case common.ExecPhaseOnCreate:
+			willRun[name] = willRun[name] || isCreate
+		case common.ExecPhaseOnStart:
+			willRun[name] = willRun[name] || isCreate || isUpdate
+		case common.ExecPhaseBeforeStop, common.ExecPhaseAfterUpdate:
+			willRun[name] = willRun[name] || isUpdate
+		}
+	}
+
+	for name, exec := range planExecs {
+		if willRun[name] {
+			exec.Stdout = types.StringUnknown()
+			exec.Stderr = types.StringUnknown()
+			exec.ExitCode = types.Int64Unknown()
+			exec.LastRunAt = types.StringUnknown()
+		} else {
+			stateExec, ok := stateExecs[name]
+			if !ok {
+				stateExec = common.InstanceExecModel{
+					Stdout:    types.StringNull(),
+					Stderr:    types.StringNull(),
+					ExitCode:  types.Int64Null(),
+					LastRunAt: types.StringNull(),
+				}
+			}
+
+			exec.Stdout = stateExec.Stdout
+			exec.Stderr = stateExec.Stderr
+			exec.ExitCode = stateExec.ExitCode
+			exec.LastRunAt = stateExec.LastRunAt
+		}
+
+		// Output is only stored if it is captured.
+		if !exec.CaptureOutput.IsUnknown() && !exec.CaptureOutput.ValueBool() {
+			exec.Stdout = types.StringNull()
+			exec.Stderr = types.StringNull()
+		}
+
+		planExecs[name] = exec
+	}
+
+	return types.MapValueFrom(ctx, planExec.ElementType(ctx), planExecs)
+}
+
+// hasUnknownExecConfig returns true if any of the settings of the exec entry
+// is not known yet.
+func hasUnknownExecConfig(ctx context.Context, exec common.InstanceExecModel) bool {
+	values := []attr.Value{exec.Command, exec.Environment, exec.WorkingDir, exec.UserID, exec.GroupID, exec.Timeout, exec.Trigger, exec.Phase}
+	for _, value := range values {
+		tfValue, err := value.ToTerraformValue(ctx)
+		if err != nil || !tfValue.IsFullyKnown() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// collectPhaseExecRuns returns the ordered exec entries of the given
+// lifecycle phase.
+func collectPhaseExecRuns(ctx context.Context, execs map[string]common.InstanceExecModel, phase string) ([]execRun, diag.Diagnostics) {
+	runs := make([]execRun, 0, len(execs))
+	for _, name := range utils.SortMapKeys(execs) {
+		if execs[name].Phase.ValueString() != phase {
+			continue
+		}
+
+		execConfig, diags := common.ToExecConfig(ctx, execs[name])
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		runs = append(runs, execRun{Name: name, ExecConfig: execConfig})
+	}
+
+	return runs, nil
+}
+
 // getExecStateConfig returns the normalized exec config from state along with a
 // flag indicating whether the entry exists in state.
 func getExecStateConfig(ctx context.Context, stateExec map[string]common.InstanceExecModel, name string) (common.InstanceExecConfig, bool, diag.Diagnostics) {
@@ -1237,22 +1741,98 @@ func getExecStateConfig(ctx context.Context, stateExec map[string]common.Instanc
 		return common.InstanceExecConfig{}, false, diags
 	}
 
-	return stateSpec, true, nil
-}
+	return stateSpec, true, nil
+}
+
+func shouldRunExec(planExecConfig common.InstanceExecConfig, stateExecConfig common.InstanceExecConfig, hasState bool, isCreate bool) bool {
+	// Entries with a lifecycle phase only run in their phase.
+	if planExecConfig.Phase != "" {
+		return false
+	}
+
+	switch planExecConfig.Trigger {
+	case "once":
+		return isCreate
+	case "on_change":
+		if isCreate || !hasState {
+			return true
+		}
+
+		return !common.ExecConfigEqual(planExecConfig, stateExecConfig)
+	default:
+		return false
+	}
+}
+
+// runTriggeredExecs runs the exec entries without a lifecycle phase based on
+// their trigger and adds their results to results.
+func runTriggeredExecs(ctx context.Context, server incus.InstanceServer, instanceName string, isVirtualMachine bool, planExec map[string]common.InstanceExecModel, stateExec map[string]common.InstanceExecModel, isCreate bool, results map[string]common.InstanceExecResult) diag.Diagnostics {
+	runs, diags := collectExecRuns(ctx, planExec, stateExec, isCreate)
+	if diags.HasError() {
+		return diags
+	}
+
+	return runExecs(ctx, server, instanceName, isVirtualMachine, runs, results)
+}
+
+// runPhaseExecs runs the exec entries of the given lifecycle phase and adds
+// their results to results.
+func runPhaseExecs(ctx context.Context, server incus.InstanceServer, instanceName string, isVirtualMachine bool, execs map[string]common.InstanceExecModel, phase string, results map[string]common.InstanceExecResult) diag.Diagnostics {
+	runs, diags := collectPhaseExecRuns(ctx, execs, phase)
+	if diags.HasError() {
+		return diags
+	}
+
+	return runExecs(ctx, server, instanceName, isVirtualMachine, runs, results)
+}
+
+// runExecs runs the exec commands in order and adds their results to results,
+// if it is not nil. Running stops at the first failing command, unless its
+// failure policy is "continue".
+func runExecs(ctx context.Context, server incus.InstanceServer, instanceName string, isVirtualMachine bool, runs []execRun, results map[string]common.InstanceExecResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(runs) == 0 {
+		return nil
+	}
+
+	if isVirtualMachine {
+		diags := waitForInstanceAgent(ctx, server, instanceName)
+		if diags != nil {
+			return diags
+		}
+	}
+
+	for _, run := range runs {
+		result, err := common.RunInstanceExec(ctx, server, instanceName, run.ExecConfig)
+		if err != nil {
+			summary := fmt.Sprintf("Failed to execute command %q in instance %q", run.Name, instanceName)
+			if run.ExecConfig.OnFailure != common.ExecOnFailureContinue {
+				diags.AddError(summary, formatExecError(err, result.Stdout, result.Stderr))
+				return diags
+			}
+
+			diags.AddWarning(summary, formatExecError(err, result.Stdout, result.Stderr))
 
-func shouldRunExec(planExecConfig common.InstanceExecConfig, stateExecConfig common.InstanceExecConfig, hasState bool, isCreate bool) bool {
-	switch planExecConfig.Trigger {
-	case "once":
-		return isCreate
-	case "on_change":
-		if isCreate || !hasState {
-			return true
+			// The command could not be run or did not finish.
+			if result.ExitCode == 0 {
+				result.ExitCode = -1
+			}
 		}
 
-		return !common.ExecConfigEqual(planExecConfig, stateExecConfig)
-	default:
-		return false
+		if result.Truncated {
+			diags.AddWarning(
+				fmt.Sprintf("Output of command %q in instance %q truncated", run.Name, instanceName),
+				fmt.Sprintf("The output exceeded %d bytes. Use %q to increase the limit.", run.ExecConfig.MaxOutputSize, "max_output_size"),
+			)
+		}
+
+		if results != nil {
+			results[run.Name] = result
+		}
 	}
+
+	return diags
 }
 
 func formatExecError(err error, stdout string, stderr string) string {
@@ -1281,6 +1861,15 @@ func (r InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	target := state.Target.ValueString()
@@ -1292,6 +1881,32 @@ func (r InstanceResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 	instanceName := state.Name.ValueString()
 
+	// Run the exec commands of the before_destroy and before_stop phases,
+	// e.g. to drain services, while the instance is still running.
+	execs, diags := common.ToExecMap(ctx, state.Exec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(execs) > 0 {
+		instanceState, _, err := server.GetInstanceState(instanceName)
+		if err != nil && !errors.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+			return
+		}
+
+		if err == nil && isInstanceRunning(*instanceState) {
+			for _, phase := range []string{common.ExecPhaseBeforeDestroy, common.ExecPhaseBeforeStop} {
+				diags := runPhaseExecs(ctx, server, instanceName, state.IsVirtualMachine(), execs, phase, nil)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+			}
+		}
+	}
+
 	// Force stop the instance, because we are deleting it anyway.
 	isFound, diag := stopInstance(ctx, server, instanceName, true)
 	if diag != nil {
@@ -1323,9 +1938,9 @@ func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportS
 		AllowedOptions: []string{"image"},
 	}
 
-	fields, diag := meta.ParseImportID(req.ID)
-	if diag != nil {
-		resp.Diagnostics.Append(diag)
+	fields, diags := meta.ParseImportRequest(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -1364,43 +1979,18 @@ func (r InstanceResource) SyncState(ctx context.Context, tfState *tfsdk.State, s
 	m.IPv6 = types.StringNull()
 	m.MAC = types.StringNull()
 
-	// First there is an access_interface set, extract IPv4, IPv6, and
-	// MAC addresses from it.
-	accIface, ok := instance.Config["user.access_interface"]
-	if ok {
-		ipv4, ipv6, mac, _, found := getAddresses(accIface, instanceState.Network[accIface])
-
-		if found {
-			if ipv4 != "" {
-				m.IPv4 = types.StringValue(ipv4)
-			}
-
-			if ipv6 != "" {
-				m.IPv6 = types.StringValue(ipv6)
-			}
-
-			if mac != "" {
-				m.MAC = types.StringValue(mac)
-			}
-		}
-	} else {
-		// If the above wasn't successful, try to automatically determine
-		// the IPv4, IPv6, and MAC addresses.
-		ipv4, ipv6, mac, _, found := findAddresses(instanceState)
+	ipv4, ipv6, mac := instanceAddresses(instance, instanceState)
 
-		if found {
-			if ipv4 != "" {
-				m.IPv4 = types.StringValue(ipv4)
-			}
+	if ipv4 != "" {
+		m.IPv4 = types.StringValue(ipv4)
+	}
 
-			if ipv6 != "" {
-				m.IPv6 = types.StringValue(ipv6)
-			}
+	if ipv6 != "" {
+		m.IPv6 = types.StringValue(ipv6)
+	}
 
-			if mac != "" {
-				m.MAC = types.StringValue(mac)
-			}
-		}
+	if mac != "" {
+		m.MAC = types.StringValue(mac)
 	}
 
 	// Extract user defined config and merge it with current resource config.
@@ -1435,6 +2025,7 @@ func (r InstanceResource) SyncState(ctx context.Context, tfState *tfsdk.State, s
 	m.Description = types.StringValue(instance.Description)
 	m.Ephemeral = types.BoolValue(instance.Ephemeral)
 	m.Status = types.StringValue(instance.Status)
+	m.Location = types.StringValue(instance.Location)
 	m.Profiles = profiles
 	m.Devices = devices
 	m.Config = config
@@ -1446,6 +2037,15 @@ func (r InstanceResource) SyncState(ctx context.Context, tfState *tfsdk.State, s
 	// does not match the expected one.
 	m.Running = types.BoolValue(isInstanceRunning(*instanceState))
 
+	// Imported instances have no move and restore settings.
+	if m.MoveStrategy.IsNull() {
+		m.MoveStrategy = types.StringValue("replace")
+	}
+
+	if m.RestoreStateful.IsNull() {
+		m.RestoreStateful = types.BoolValue(false)
+	}
+
 	plannedTarget := m.Target.ValueString()
 	actualTarget := ""
 	clustered := server.IsClustered()
@@ -1472,13 +2072,7 @@ func (r InstanceResource) createInstanceFromImage(ctx context.Context, server in
 		return diags
 	}
 
-	image := plan.Image.ValueString()
-	imageRemote := ""
-	imageParts := strings.SplitN(image, ":", 2)
-	if len(imageParts) == 2 {
-		imageRemote = imageParts[0]
-		image = imageParts[1]
-	}
+	imageRemote, image := common.ParseImageRef(plan.Image.ValueString())
 
 	var imageServer incus.ImageServer
 	if imageRemote == "" {
@@ -1520,7 +2114,7 @@ func (r InstanceResource) createInstanceFromImage(ctx context.Context, server in
 	// Initialize the instance. Instance will not be running after this call.
 	if err == nil {
 		// Wait for the instance to be created.
-		err = opCreate.Wait()
+		err = opCreate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1580,7 +2174,7 @@ func (r InstanceResource) createInstanceFromSourceFile(ctx context.Context, serv
 
 	op, err := server.CreateInstanceFromBackup(createArgs)
 	if err == nil {
-		err = op.Wait()
+		err = op.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1669,7 +2263,7 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 
 		opCreate, err := destServer.CopyInstance(sourceServer, *sourceInstance, &args)
 		if err == nil {
-			err = opCreate.Wait()
+			err = opCreate.WaitContext(ctx)
 		}
 
 		if err != nil {
@@ -1733,7 +2327,7 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 
 	opCreate, err := destServer.CopyInstanceSnapshot(sourceServer, sourceInstanceName, *sourceSnapshot, &args)
 	if err == nil {
-		err = opCreate.Wait()
+		err = opCreate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1744,6 +2338,158 @@ func (r InstanceResource) createInstanceFromSourceInstance(ctx context.Context,
 	return diags
 }
 
+// moveInstance moves an instance to the project and remote defined in the
+// plan. The instance is copied to its destination, started according to
+// "running" and verified using "wait_for". The source instance is deleted
+// only once the destination is healthy. Otherwise, the destination instance
+// is removed and the source instance is restored to its previous state.
+// The "before_stop" exec entries run before the source instance is stopped.
+func (r InstanceResource) moveInstance(ctx context.Context, destServer incus.InstanceServer, plan InstanceModel, state InstanceModel, execs map[string]common.InstanceExecModel, execResults map[string]common.InstanceExecResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instanceName := state.Name.ValueString()
+	sourceRemote := state.Remote.ValueString()
+	sourceProject := state.Project.ValueString()
+	sourceServer, err := r.provider.InstanceServer(sourceRemote, sourceProject, "")
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		return diags
+	}
+
+	sourceInstanceState, _, err := sourceServer.GetInstanceState(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	wasRunning := isInstanceRunning(*sourceInstanceState)
+
+	// Stop the source instance to get a consistent copy.
+	diags.Append(stopInstanceGracefully(ctx, sourceServer, instanceName, state.IsVirtualMachine(), execs, execResults)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Rolling back must not be cut short by the timeout of the move itself.
+	cleanupCtx := context.WithoutCancel(ctx)
+
+	// Restores the source instance to its previous running state.
+	restoreSource := func() {
+		if wasRunning {
+			diag := startInstance(cleanupCtx, sourceServer, instanceName)
+			if diag != nil {
+				diags.Append(diag)
+			}
+		}
+	}
+
+	sourceInstance, _, err := sourceServer.GetInstance(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve instance %q", instanceName), err.Error())
+		restoreSource()
+		return diags
+	}
+
+	// Profiles must exist in the destination project.
+	profiles, profileDiags := ToProfileList(ctx, plan.Profiles)
+	diags.Append(profileDiags...)
+	if diags.HasError() {
+		restoreSource()
+		return diags
+	}
+
+	sourceInstance.Profiles = profiles
+
+	remoteCopy := sourceRemote != plan.Remote.ValueString()
+	for k := range sourceInstance.Config {
+		if !instanceIncludeWhenCopying(k, remoteCopy) {
+			delete(sourceInstance.Config, k)
+		}
+	}
+
+	args := incus.InstanceCopyArgs{
+		Name:              instanceName,
+		Live:              false,
+		InstanceOnly:      false,
+		Refresh:           false,
+		AllowInconsistent: false,
+	}
+
+	opCopy, err := destServer.CopyInstance(sourceServer, *sourceInstance, &args)
+	if err == nil {
+		err = opCopy.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to copy instance %q to its destination", instanceName), err.Error())
+		restoreSource()
+		return diags
+	}
+
+	// Verify the destination instance is healthy.
+	var verifyDiags diag.Diagnostics
+	if plan.Running.ValueBool() {
+		diag := startInstance(ctx, destServer, instanceName)
+		if diag != nil {
+			verifyDiags.Append(diag)
+		} else if len(plan.WaitForConfigs.Elements()) > 0 {
+			verifyDiags.Append(waitFor(ctx, destServer, instanceName, plan.WaitForConfigs, plan.IsVirtualMachine())...)
+		}
+	}
+
+	if verifyDiags.HasError() {
+		diags.Append(verifyDiags...)
+
+		// Remove the destination instance.
+		_, diag := stopInstance(cleanupCtx, destServer, instanceName, true)
+		if diag != nil {
+			diags.Append(diag)
+		}
+
+		opDelete, err := destServer.DeleteInstance(instanceName)
+		if err == nil {
+			err = opDelete.WaitContext(cleanupCtx)
+		}
+
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Failed to remove instance %q from its destination", instanceName), err.Error())
+		}
+
+		restoreSource()
+		return diags
+	}
+
+	// Remove the source instance.
+	opDelete, err := sourceServer.DeleteInstance(instanceName)
+	if err == nil {
+		err = opDelete.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to remove instance %q from project %q after moving it", instanceName, sourceProject), err.Error())
+		return diags
+	}
+
+	return diags
+}
+
+// requiresReplaceUnlessMoved returns a plan modifier that requires the
+// instance to be replaced, unless "move_strategy" is set to "move".
+func requiresReplaceUnlessMoved() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			var moveStrategy types.String
+
+			diags := req.Plan.GetAttribute(ctx, path.Root("move_strategy"), &moveStrategy)
+			resp.Diagnostics.Append(diags...)
+
+			resp.RequiresReplace = moveStrategy.ValueString() != "move"
+		},
+		`Requires replacement unless "move_strategy" is set to "move".`,
+		`Requires replacement unless "move_strategy" is set to "move".`,
+	)
+}
+
 func instanceIncludeWhenCopying(configKey string, remoteCopy bool) bool {
 	if configKey == "volatile.base_image" {
 		return true // Include volatile.base_image always as it can help optimize copies.
@@ -1776,7 +2522,7 @@ func (r InstanceResource) createInstanceWithoutImage(ctx context.Context, server
 	// Initialize the instance. Instance will not be running after this call.
 	if err == nil {
 		// Wait for the instance to be created.
-		err = opCreate.Wait()
+		err = opCreate.WaitContext(ctx)
 	}
 
 	if err != nil {
@@ -1956,6 +2702,156 @@ func stopInstance(ctx context.Context, server incus.InstanceServer, instanceName
 	return true, nil
 }
 
+// stopInstanceGracefully stops an instance with the given name gracefully.
+// If the instance is running, the "before_stop" exec entries run first and
+// their results are added to results.
+func stopInstanceGracefully(ctx context.Context, server incus.InstanceServer, instanceName string, isVirtualMachine bool, execs map[string]common.InstanceExecModel, results map[string]common.InstanceExecResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instanceState, _, err := server.GetInstanceState(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	if isInstanceRunning(*instanceState) {
+		diags.Append(runPhaseExecs(ctx, server, instanceName, isVirtualMachine, execs, common.ExecPhaseBeforeStop, results)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	_, diag := stopInstance(ctx, server, instanceName, false)
+	if diag != nil {
+		diags.Append(diag)
+	}
+
+	return diags
+}
+
+// restoreInstance restores an instance with the given name from the given
+// snapshot. The instance is stopped gracefully before the restore, running
+// the "before_stop" exec entries. If stateful is true, the instance's
+// runtime state is restored as well, which leaves the instance running
+// afterwards.
+func restoreInstance(ctx context.Context, server incus.InstanceServer, instanceName string, snapshotName string, stateful bool, isVirtualMachine bool, execs map[string]common.InstanceExecModel, execResults map[string]common.InstanceExecResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(stopInstanceGracefully(ctx, server, instanceName, isVirtualMachine, execs, execResults)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	instance, etag, err := server.GetInstance(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve existing instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	restoreReq := api.InstancePut{
+		Description:  instance.Description,
+		Ephemeral:    instance.Ephemeral,
+		Architecture: instance.Architecture,
+		Restore:      snapshotName,
+		Stateful:     stateful,
+		Config:       instance.Config,
+		Profiles:     instance.Profiles,
+		Devices:      instance.Devices,
+	}
+
+	op, err := server.UpdateInstance(instanceName, restoreReq, etag)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to restore instance %q from snapshot %q", instanceName, snapshotName), err.Error())
+		return diags
+	}
+
+	return diags
+}
+
+// migrateInstance moves an instance with the given name to another cluster
+// member and/or storage pool. Running virtual machines with
+// "migration.stateful" enabled are live migrated to the new cluster member.
+// Otherwise, the instance is stopped gracefully, running the "before_stop"
+// exec entries, and migrated cold, leaving it stopped.
+func migrateInstance(ctx context.Context, server incus.InstanceServer, instanceName string, target string, pool string, execs map[string]common.InstanceExecModel, execResults map[string]common.InstanceExecResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	instance, _, err := server.GetInstance(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve existing instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	instanceState, _, err := server.GetInstanceState(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	live := target != "" &&
+		instance.Type == "virtual-machine" &&
+		isInstanceRunning(*instanceState) &&
+		util.IsTrue(instance.ExpandedConfig["migration.stateful"])
+
+	if !live {
+		diags.Append(stopInstanceGracefully(ctx, server, instanceName, instance.Type == "virtual-machine", execs, execResults)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	migrateReq := api.InstancePost{
+		Name:      instanceName,
+		Migration: true,
+		Live:      live,
+		Pool:      pool,
+	}
+
+	if target != "" {
+		server = server.UseTarget(target)
+	}
+
+	op, err := server.MigrateInstance(instanceName, migrateReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to migrate instance %q", instanceName), err.Error())
+		return diags
+	}
+
+	return diags
+}
+
+// rootDiskPool returns the storage pool of the root disk device from the
+// given set of devices. An empty string is returned if no root disk device
+// with a pool is defined.
+func rootDiskPool(ctx context.Context, deviceSet types.Set) (string, diag.Diagnostics) {
+	devices, diags := common.ToDeviceMap(ctx, deviceSet)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	return rootDiskPoolFromDevices(devices), nil
+}
+
+// rootDiskPoolFromDevices returns the storage pool of the root disk device from
+// the given devices, such as the expanded devices of an instance.
+func rootDiskPoolFromDevices(devices map[string]map[string]string) string {
+	for _, device := range devices {
+		if device["type"] == "disk" && device["path"] == "/" {
+			return device["pool"]
+		}
+	}
+
+	return ""
+}
+
 // waitFor waits for the instance with the given name to reach the desired
 // state. It returns an error if the instance does not reach the desired
 // state within the given timeout.
@@ -2126,18 +3022,18 @@ func waitForInstanceCloudInit(ctx context.Context, server incus.InstanceServer,
 		Command: []string{"cloud-init", "status", "--wait", "--format", "json"},
 	}
 
-	stdout, stderr, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
+	result, err := common.RunInstanceExec(ctx, server, instanceName, execConfig)
 	if err != nil {
 		var diags diag.Diagnostics
 		diags.AddError(
 			fmt.Sprintf("Failed to wait for cloud-init in instance %q", instanceName),
-			formatExecError(err, stdout, stderr),
+			formatExecError(err, result.Stdout, result.Stderr),
 		)
 		return diags
 	}
 
 	var status cloudInitStatus
-	if err := json.Unmarshal([]byte(stdout), &status); err != nil {
+	if err := json.Unmarshal([]byte(result.Stdout), &status); err != nil {
 		return nil
 	}
 
@@ -2161,7 +3057,7 @@ func waitForState(ctx context.Context, refreshFunc retry.StateRefreshFunc, targe
 	stateRefreshConf := &retry.StateChangeConf{
 		Refresh:    refreshFunc,
 		Target:     targets,
-		Timeout:    3 * time.Minute,
+		Timeout:    utils.ContextDuration(ctx, 3*time.Minute),
 		MinTimeout: 2 * time.Second, // Timeout increases: 2, 4, 8, 10, 10, ...
 		Delay:      2 * time.Second, // Delay before the first check/refresh.
 	}
@@ -2256,6 +3152,44 @@ func (s sortedInterfaces) Less(i, j int) bool {
 	return false
 }
 
+// instanceAddresses returns the IPv4, IPv6, and MAC addresses of the instance.
+// If set, the addresses of the access_interface are used, otherwise they are
+// determined automatically.
+func instanceAddresses(instance *api.Instance, instanceState *api.InstanceState) (string, string, string) {
+	var ipv4, ipv6, mac string
+
+	accIface, ok := instance.Config["user.access_interface"]
+	if ok {
+		ipv4, ipv6, mac, _, _ = getAddresses(accIface, instanceState.Network[accIface])
+	} else {
+		ipv4, ipv6, mac, _, _ = findAddresses(instanceState)
+	}
+
+	return ipv4, ipv6, mac
+}
+
+// instanceFileTemplateData returns the data available to templated files
+// based on the current state of the instance.
+func instanceFileTemplateData(ctx context.Context, server incus.InstanceServer, instanceName string) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	instance, _, err := server.GetInstance(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve instance %q", instanceName), err.Error())
+		return nil, diags
+	}
+
+	instanceState, _, err := server.GetInstanceState(instanceName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Failed to retrieve state of instance %q", instanceName), err.Error())
+		return nil, diags
+	}
+
+	ipv4, _, _ := instanceAddresses(instance, instanceState)
+
+	return common.FileTemplateData(ctx, instance, instanceState, ipv4)
+}
+
 // findAddresses looks for the most optimal interface on the instance to return
 // the IPv4, IPv6 and MAC address and interface name from.
 func findAddresses(state *api.InstanceState) (string, string, string, string, bool) {
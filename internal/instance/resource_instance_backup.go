@@ -0,0 +1,377 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type InstanceBackupModel struct {
+	Name                 types.String `tfsdk:"name"`
+	Instance             types.String `tfsdk:"instance"`
+	InstanceOnly         types.Bool   `tfsdk:"instance_only"`
+	OptimizedStorage     types.Bool   `tfsdk:"optimized_storage"`
+	CompressionAlgorithm types.String `tfsdk:"compression_algorithm"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+	OutputPath           types.String `tfsdk:"output_path"`
+	Project              types.String `tfsdk:"project"`
+	Remote               types.String `tfsdk:"remote"`
+
+	// Computed.
+	CreatedAt types.Int64  `tfsdk:"created_at"`
+	Size      types.Int64  `tfsdk:"size"`
+	SHA256    types.String `tfsdk:"sha256"`
+}
+
+// InstanceBackupResource represent Incus instance backup resource.
+type InstanceBackupResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewInstanceBackupResource returns a new instance backup resource.
+func NewInstanceBackupResource() resource.Resource {
+	return &InstanceBackupResource{}
+}
+
+func (r InstanceBackupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_instance_backup", req.ProviderTypeName)
+}
+
+func (r InstanceBackupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"instance": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"instance_only": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"optimized_storage": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"compression_algorithm": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					common.RFC3339Validator{},
+				},
+			},
+
+			"output_path": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"created_at": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"size": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"sha256": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *InstanceBackupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// ModifyPlan plans the replacement of a backup whose downloaded file was
+// removed or modified, so that the backup is downloaded again.
+func (r InstanceBackupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.PlanBackupFile(ctx, req, resp)
+}
+
+func (r InstanceBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan InstanceBackupModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := plan.Instance.ValueString()
+	backupName := plan.Name.ValueString()
+
+	backupReq := api.InstanceBackupsPost{
+		Name:                 backupName,
+		InstanceOnly:         plan.InstanceOnly.ValueBool(),
+		OptimizedStorage:     plan.OptimizedStorage.ValueBool(),
+		CompressionAlgorithm: plan.CompressionAlgorithm.ValueString(),
+	}
+
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid backup expiry", err.Error())
+		return
+	}
+
+	if expiresAt != nil {
+		backupReq.ExpiresAt = *expiresAt
+	}
+
+	op, err := server.CreateInstanceBackup(instanceName, backupReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create backup %q for instance %q", backupName, instanceName), err.Error())
+		return
+	}
+
+	// Download the backup if an output path is provided.
+	download := func(req *incus.BackupFileRequest) (*incus.BackupFileResponse, error) {
+		return server.GetInstanceBackupFile(instanceName, backupName, req)
+	}
+
+	plan.Size, plan.SHA256, err = common.DownloadBackupFile(plan.OutputPath, download)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to download backup %q of instance %q", backupName, instanceName), err.Error())
+
+		// Remove the backup, as it is not tracked in the state.
+		op, err := server.DeleteInstanceBackup(instanceName, backupName)
+		if err == nil {
+			_ = op.WaitContext(ctx)
+		}
+
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r InstanceBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state InstanceBackupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Verify the downloaded backup file.
+	err = common.SyncBackupFile(state.OutputPath, &state.Size, &state.SHA256)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("output_path"), "Failed to verify backup file", err.Error())
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is a no-op, as every attribute change requires a new backup.
+func (r InstanceBackupResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+// Delete removes the backup from the server. A downloaded backup file is
+// left untouched.
+func (r InstanceBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state InstanceBackupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	instanceName := state.Instance.ValueString()
+	backupName := state.Name.ValueString()
+
+	op, err := server.DeleteInstanceBackup(instanceName, backupName)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	// The backup may have already expired and been removed by Incus.
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove backup %q of instance %q", backupName, instanceName), err.Error())
+	}
+}
+
+func (r InstanceBackupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "instance_backup",
+		RequiredFields: []string{"instance", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the server's current state for an instance backup and
+// updates the provided model. It then applies this updated model as the
+// new state in Terraform.
+func (r InstanceBackupResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m InstanceBackupModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	instanceName := m.Instance.ValueString()
+	backupName := m.Name.ValueString()
+
+	backup, _, err := server.GetInstanceBackup(instanceName, backupName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve backup %q of instance %q", backupName, instanceName), err.Error())
+		return respDiags
+	}
+
+	m.Name = types.StringValue(backup.Name)
+	m.InstanceOnly = types.BoolValue(backup.InstanceOnly)
+	m.OptimizedStorage = types.BoolValue(backup.OptimizedStorage)
+	m.CreatedAt = types.Int64Value(backup.CreatedAt.Unix())
+
+	m.ExpiresAt = common.ToTimestampType(backup.ExpiresAt, m.ExpiresAt)
+
+	return tfState.Set(ctx, &m)
+}
@@ -0,0 +1,132 @@
+package instance_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccInstanceBackup_basic(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceBackup_basic(instanceName, backupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "instance", instanceName),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "instance_only", "false"),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "optimized_storage", "false"),
+					resource.TestCheckResourceAttrSet("incus_instance_backup.backup1", "created_at"),
+					resource.TestCheckNoResourceAttr("incus_instance_backup.backup1", "size"),
+					resource.TestCheckNoResourceAttr("incus_instance_backup.backup1", "sha256"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceBackup_download(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+	outputPath := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceBackup_download(instanceName, backupName, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "instance_only", "true"),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "compression_algorithm", "gzip"),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "expires_at", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("incus_instance_backup.backup1", "output_path", outputPath),
+					resource.TestCheckResourceAttrSet("incus_instance_backup.backup1", "size"),
+					resource.TestCheckResourceAttrSet("incus_instance_backup.backup1", "sha256"),
+				),
+			},
+			{
+				// Removing the downloaded file results in a new backup.
+				PreConfig: func() {
+					err := os.Remove(outputPath)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccInstanceBackup_download(instanceName, backupName, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("incus_instance_backup.backup1", "size"),
+					resource.TestCheckResourceAttrSet("incus_instance_backup.backup1", "sha256"),
+					acctest.TestCheckFileExists(outputPath),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstanceBackup_importBasic(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+	resourceName := "incus_instance_backup.backup1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceBackup_basic(instanceName, backupName),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        fmt.Sprintf("/%s/%s", instanceName, backupName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+			},
+		},
+	})
+}
+
+func testAccInstanceBackup_basic(instanceName, backupName string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+}
+
+resource "incus_instance_backup" "backup1" {
+  name     = "%s"
+  instance = incus_instance.instance1.name
+}
+`, instanceName, acctest.TestImage, backupName)
+}
+
+func testAccInstanceBackup_download(instanceName, backupName, outputPath string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+}
+
+resource "incus_instance_backup" "backup1" {
+  name                  = "%s"
+  instance              = incus_instance.instance1.name
+  instance_only         = true
+  compression_algorithm = "gzip"
+  expires_at            = "2099-01-01T00:00:00Z"
+  output_path           = "%s"
+}
+`, instanceName, acctest.TestImage, backupName, outputPath)
+}
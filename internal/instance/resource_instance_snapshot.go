@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +22,7 @@ import (
 	incus "github.com/lxc/incus/v7/client"
 	"github.com/lxc/incus/v7/shared/api"
 
+	"github.com/lxc/terraform-provider-incus/internal/common"
 	"github.com/lxc/terraform-provider-incus/internal/errors"
 	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
 )
@@ -32,6 +34,8 @@ type InstanceSnapshotModel struct {
 	Project  types.String `tfsdk:"project"`
 	Remote   types.String `tfsdk:"remote"`
 
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
 	// Computed.
 	CreatedAt types.Int64 `tfsdk:"created_at"`
 }
@@ -50,7 +54,7 @@ func (r InstanceSnapshotResource) Metadata(_ context.Context, req resource.Metad
 	resp.TypeName = fmt.Sprintf("%s_instance_snapshot", req.ProviderTypeName)
 }
 
-func (r InstanceSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r InstanceSnapshotResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -99,6 +103,10 @@ func (r InstanceSnapshotResource) Schema(_ context.Context, _ resource.SchemaReq
 				Computed: true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -127,6 +135,15 @@ func (r InstanceSnapshotResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -152,7 +169,7 @@ func (r InstanceSnapshotResource) Create(ctx context.Context, req resource.Creat
 		}
 
 		// Wait for snapshot operation to complete.
-		serr = op.Wait()
+		serr = op.WaitContext(ctx)
 		if serr == nil {
 			break
 		}
@@ -188,6 +205,15 @@ func (r InstanceSnapshotResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -214,6 +240,15 @@ func (r InstanceSnapshotResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	server, err := r.provider.InstanceServer(remote, project, "")
@@ -230,7 +265,7 @@ func (r InstanceSnapshotResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err = op.Wait()
+	err = op.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove snapshot %q for instance %q", snapshotName, instanceName), err.Error())
 	}
@@ -118,6 +118,44 @@ func TestAccInstanceSnapshot_project(t *testing.T) {
 	})
 }
 
+func TestAccInstanceSnapshot_restore(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceSnapshot_basic(instanceName, snapshotName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckNoResourceAttr("incus_instance.instance1", "restore_from_snapshot"),
+					resource.TestCheckResourceAttr("incus_instance_snapshot.snapshot1", "name", snapshotName),
+				),
+			},
+			{
+				Config: testAccInstanceSnapshot_restore(instanceName, snapshotName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "restore_from_snapshot", snapshotName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "restore_stateful", "false"),
+				),
+			},
+			{
+				Config: testAccInstanceSnapshot_restore(instanceName, snapshotName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Stopped"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "restore_from_snapshot", snapshotName),
+				),
+			},
+		},
+	})
+}
+
 func testAccInstanceSnapshot_basic(cName, sName string, stateful bool) string {
 	return fmt.Sprintf(`
 resource "incus_instance" "instance1" {
@@ -194,3 +232,20 @@ resource "incus_instance_snapshot" "snapshot1" {
 }
 	`, project, instance, acctest.TestImage, snapshot)
 }
+
+func testAccInstanceSnapshot_restore(cName, sName string, running bool) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name                  = "%[1]s"
+  image                 = "%[2]s"
+  running               = %[4]v
+  restore_from_snapshot = "%[3]s"
+}
+
+resource "incus_instance_snapshot" "snapshot1" {
+  instance = incus_instance.instance1.name
+  name     = "%[3]s"
+  stateful = false
+}
+	`, cName, acctest.TestImage, sName, running)
+}
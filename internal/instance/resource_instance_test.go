@@ -2,6 +2,7 @@ package instance_test
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 
@@ -37,6 +39,33 @@ func TestAccInstance_basic(t *testing.T) {
 	})
 }
 
+func TestAccInstance_timeouts(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_timeouts(instanceName, acctest.TestImage, "10m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "timeouts.create", "10m"),
+				),
+			},
+			{
+				Config: testAccInstance_timeouts(instanceName, acctest.TestImage, "15m"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "timeouts.create", "15m"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_noImage(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
@@ -268,6 +297,100 @@ func TestAccInstance_execTimeout(t *testing.T) {
 	})
 }
 
+func TestAccInstance_execCaptureOutput(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_execCaptureOutput(instanceName, "v1", 1024),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.capture.stdout", "hello\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.capture.stderr", "oops\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.capture.exit_code", "0"),
+					resource.TestCheckResourceAttrSet("incus_instance.instance1", "exec.capture.last_run_at"),
+					resource.TestCheckNoResourceAttr("incus_instance.instance1", "exec.discard.stdout"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.discard.exit_code", "0"),
+				),
+			},
+			{
+				// Outputs are kept if the commands do not run again.
+				Config: testAccInstance_execCaptureOutput(instanceName, "v2", 1024),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("incus_instance.instance1", tfjsonpath.New("exec").AtMapKey("capture").AtMapKey("stdout"), knownvalue.StringExact("hello\n")),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "description", "v2"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.capture.stdout", "hello\n"),
+				),
+			},
+			{
+				// Output exceeding the limit is truncated.
+				Config: testAccInstance_execCaptureOutputTruncated(instanceName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.capture.stdout", "hel"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_execPhases(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_execPhases(instanceName, "v1", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.create.stdout", "create\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.start.stdout", "start\n"),
+					resource.TestCheckNoResourceAttr("incus_instance.instance1", "exec.stop.last_run_at"),
+					resource.TestCheckNoResourceAttr("incus_instance.instance1", "exec.update.last_run_at"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.failing.exit_code", "1"),
+				),
+			},
+			{
+				// Updates run the after_update phase.
+				Config: testAccInstance_execPhases(instanceName, "v2", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.update.stdout", "update\n"),
+					resource.TestCheckNoResourceAttr("incus_instance.instance1", "exec.stop.last_run_at"),
+				),
+			},
+			{
+				// Stopping the instance runs the before_stop phase.
+				Config: testAccInstance_execPhases(instanceName, "v2", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Stopped"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "exec.stop.stdout", "stop\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_execPhaseFailure(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccInstance_execPhaseFailure(instanceName),
+				ExpectError: regexp.MustCompile("Failed to execute command"),
+			},
+		},
+	})
+}
+
 func TestAccInstance_waitForFailureKeepsStateInProject(t *testing.T) {
 	projectName := petname.Generate(2, "-")
 	instanceName := petname.Generate(2, "-")
@@ -824,6 +947,63 @@ func TestAccInstance_fileUploadContent(t *testing.T) {
 	})
 }
 
+func TestAccInstance_fileTrackDrift(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_fileTrackDrift(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.#", "1"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.content", "Hello, World!\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.track_drift", "true"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.source_hash", "c98c24b677eff44860afea6f493bbaec5bb1c4cbb209c6fc2bbb47f66ff2ad31"),
+				),
+			},
+			{
+				// Changing the file in the instance results in a re-upload.
+				PreConfig:          func() { acctest.PreConfigInstanceFile(t, instanceName, "/foo/bar.txt", "Changed\n") },
+				Config:             testAccInstance_fileTrackDrift(instanceName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccInstance_fileTrackDrift(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.#", "1"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.content", "Hello, World!\n"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.source_hash", "c98c24b677eff44860afea6f493bbaec5bb1c4cbb209c6fc2bbb47f66ff2ad31"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_fileTemplate(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_fileTemplate(instanceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.#", "1"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.template", "true"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.content", "name={{ .name }} role={{ index .config \"user.role\" }}\n"),
+					acctest.TestCheckInstanceFileContent(t, instanceName, "/foo/bar.txt", fmt.Sprintf("name=%s role=web\n", instanceName)),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_fileUploadContent_VM(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
@@ -874,6 +1054,66 @@ func TestAccInstance_fileUploadSource(t *testing.T) {
 	})
 }
 
+func TestAccInstance_fileUploadDirectory(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+	sourceDir := t.TempDir()
+
+	writeFile := func(name string, content string) {
+		err := os.MkdirAll(filepath.Dir(filepath.Join(sourceDir, name)), 0o700)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(filepath.Join(sourceDir, name), []byte(content), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("app.conf", "a\n")
+	writeFile("conf.d/extra.conf", "b\n")
+	writeFile("README.md", "ignored\n")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_fileUploadDirectory(instanceName, sourceDir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.#", "1"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.recursive", "true"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "file.0.delete_extraneous", "true"),
+					resource.TestCheckResourceAttrSet("incus_instance.instance1", "file.0.source_hash"),
+				),
+			},
+			{
+				// Changes within the source directory result in an update.
+				PreConfig: func() {
+					writeFile("app.conf", "changed\n")
+					err := os.Remove(filepath.Join(sourceDir, "conf.d", "extra.conf"))
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccInstance_fileUploadDirectory(instanceName, sourceDir),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance1", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				// Changes to excluded files are ignored.
+				PreConfig: func() { writeFile("README.md", "changed\n") },
+				Config:    testAccInstance_fileUploadDirectory(instanceName, sourceDir),
+				PlanOnly:  true,
+			},
+		},
+	})
+}
+
 func TestAccInstance_configLimits(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 
@@ -1002,6 +1242,76 @@ func TestAccInstance_target(t *testing.T) {
 	})
 }
 
+func TestAccInstance_migrate(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.PreCheckClustering(t)
+		},
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_migrate(instanceName, "0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttrPair("incus_instance.instance1", "target", "incus_instance.instance1", "location"),
+				),
+			},
+			{
+				Config: testAccInstance_migrate(instanceName, "length(local.member_names) - 1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttrPair("incus_instance.instance1", "target", "incus_instance.instance1", "location"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccInstance_rootDiskFromProfile(t *testing.T) {
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_basic(instanceName, acctest.TestImage),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "device.#", "0"),
+				),
+			},
+			{
+				// Defining the root disk inherited from the default profile
+				// on the instance does not migrate it.
+				Config: testAccInstance_rootDiskFromProfile(instanceName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "device.#", "1"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "device.0.properties.pool", "default"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_createProject(t *testing.T) {
 	instanceName := petname.Generate(2, "-")
 	projectName := petname.Name()
@@ -1023,6 +1333,41 @@ func TestAccInstance_createProject(t *testing.T) {
 	})
 }
 
+func TestAccInstance_moveProject(t *testing.T) {
+	projectName1 := petname.Generate(2, "-")
+	projectName2 := petname.Generate(2, "-")
+	instanceName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstance_moveProject(projectName1, projectName2, instanceName, "project1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "project", projectName1),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "move_strategy", "move"),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+				),
+			},
+			{
+				Config: testAccInstance_moveProject(projectName1, projectName2, instanceName, "project2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_instance.instance1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_instance.instance1", "name", instanceName),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "project", projectName2),
+					resource.TestCheckResourceAttr("incus_instance.instance1", "status", "Running"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccInstance_removeProject(t *testing.T) {
 	projectName := petname.Generate(2, "-")
 	instanceName := petname.Generate(2, "-")
@@ -1552,6 +1897,21 @@ resource "incus_instance" "instance1" {
 	`, name, image)
 }
 
+func testAccInstance_timeouts(name string, image string, timeout string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  timeouts {
+    create = "%s"
+    update = "%[3]s"
+    delete = "%[3]s"
+  }
+}
+	`, name, image, timeout)
+}
+
 func testAccInstance_ociWithRemote(name string, image string) string {
 	return fmt.Sprintf(`
 provider "incus" {
@@ -1977,6 +2337,44 @@ resource "incus_instance" "instance1" {
 	`, name, acctest.TestImage)
 }
 
+func testAccInstance_fileTrackDrift(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  file {
+    content            = "Hello, World!\n"
+    target_path        = "/foo/bar.txt"
+    mode               = "0644"
+    create_directories = true
+    track_drift        = true
+  }
+}
+	`, name, acctest.TestImage)
+}
+
+func testAccInstance_fileTemplate(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  config = {
+    "user.role" = "web"
+  }
+
+  file {
+    content            = "name={{ .name }} role={{ index .config \"user.role\" }}\n"
+    target_path        = "/foo/bar.txt"
+    mode               = "0644"
+    create_directories = true
+    template           = true
+  }
+}
+	`, name, acctest.TestImage)
+}
+
 func testAccInstance_fileUploadContent_2(name string) string {
 	return fmt.Sprintf(`
 resource "incus_instance" "instance1" {
@@ -2052,6 +2450,24 @@ resource "incus_instance" "instance1" {
 	`, name, acctest.TestImage)
 }
 
+func testAccInstance_fileUploadDirectory(name string, sourceDir string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  file {
+    source_path       = "%s"
+    target_path       = "/opt/app"
+    mode              = "0644"
+    recursive         = true
+    include           = ["*.conf"]
+    delete_extraneous = true
+  }
+}
+	`, name, acctest.TestImage, sourceDir)
+}
+
 func testAccInstance_remoteImage(name string) string {
 	return fmt.Sprintf(`
 resource "incus_instance" "instance1" {
@@ -2149,6 +2565,40 @@ resource "incus_instance" "instance2" {
 	`, name, acctest.TestImage)
 }
 
+func testAccInstance_migrate(name string, memberIndex string) string {
+	return fmt.Sprintf(`
+data "incus_cluster" "test" {}
+
+locals {
+  member_names = [ for k, v in data.incus_cluster.test.members : k ]
+}
+
+resource "incus_instance" "instance1" {
+  name   = "%[1]s"
+  image  = "%[2]s"
+  target = element(tolist(local.member_names), %[3]s)
+}
+	`, name, acctest.TestImage, memberIndex)
+}
+
+func testAccInstance_rootDiskFromProfile(name string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%[1]s"
+  image = "%[2]s"
+
+  device {
+    name = "root"
+    type = "disk"
+    properties = {
+      "path" = "/"
+      "pool" = "default"
+    }
+  }
+}
+	`, name, acctest.TestImage)
+}
+
 func testAccInstance_project(projectName string, instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_project" "project1" {
@@ -2167,6 +2617,33 @@ resource "incus_instance" "instance1" {
 	`, projectName, instanceName, acctest.TestImage)
 }
 
+func testAccInstance_moveProject(projectName1, projectName2, instanceName, project string) string {
+	return fmt.Sprintf(`
+resource "incus_project" "project1" {
+  name = "%[1]s"
+  config = {
+    "features.images"   = false
+    "features.profiles" = false
+  }
+}
+
+resource "incus_project" "project2" {
+  name = "%[2]s"
+  config = {
+    "features.images"   = false
+    "features.profiles" = false
+  }
+}
+
+resource "incus_instance" "instance1" {
+  name          = "%[3]s"
+  image         = "%[4]s"
+  project       = incus_project.%[5]s.name
+  move_strategy = "move"
+}
+	`, projectName1, projectName2, instanceName, acctest.TestImage, project)
+}
+
 func testAccInstance_removeProject_1(projectName, instanceName string) string {
 	return fmt.Sprintf(`
 resource "incus_project" "project1" {
@@ -2505,6 +2982,104 @@ resource "incus_instance" "instance1" {
 `, instanceName, acctest.TestImage, description, command)
 }
 
+func testAccInstance_execCaptureOutput(instanceName, description string, maxOutputSize int) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name        = "%s"
+  image       = "%s"
+  description = "%s"
+
+  exec = {
+    "capture" = {
+      command         = ["/bin/sh", "-c", "echo hello; echo oops >&2"]
+      capture_output  = true
+      max_output_size = %d
+    }
+
+    "discard" = {
+      command = ["/bin/sh", "-c", "echo hello"]
+    }
+  }
+}
+`, instanceName, acctest.TestImage, description, maxOutputSize)
+}
+
+func testAccInstance_execCaptureOutputTruncated(instanceName string, maxOutputSize int) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  exec = {
+    "capture" = {
+      command         = ["/bin/sh", "-c", "echo hello world"]
+      capture_output  = true
+      max_output_size = %d
+    }
+  }
+}
+`, instanceName, acctest.TestImage, maxOutputSize)
+}
+
+func testAccInstance_execPhases(instanceName, description string, running bool) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name        = "%s"
+  image       = "%s"
+  description = "%s"
+  running     = %t
+
+  exec = {
+    "create" = {
+      command        = ["echo", "create"]
+      phase          = "on_create"
+      capture_output = true
+    }
+
+    "start" = {
+      command        = ["echo", "start"]
+      phase          = "on_start"
+      capture_output = true
+    }
+
+    "stop" = {
+      command        = ["echo", "stop"]
+      phase          = "before_stop"
+      capture_output = true
+    }
+
+    "update" = {
+      command        = ["echo", "update"]
+      phase          = "after_update"
+      capture_output = true
+    }
+
+    "failing" = {
+      command    = ["false"]
+      phase      = "on_create"
+      on_failure = "continue"
+    }
+  }
+}
+`, instanceName, acctest.TestImage, description, running)
+}
+
+func testAccInstance_execPhaseFailure(instanceName string) string {
+	return fmt.Sprintf(`
+resource "incus_instance" "instance1" {
+  name  = "%s"
+  image = "%s"
+
+  exec = {
+    "failing" = {
+      command = ["false"]
+      phase   = "on_create"
+    }
+  }
+}
+`, instanceName, acctest.TestImage)
+}
+
 func testAccInstance_execOnChangeVerifyFile(instanceName, description string) string {
 	filePath := fmt.Sprintf("/tmp/exec-on-change-%s", instanceName)
 	command := fmt.Sprintf("test -f %s", filePath)
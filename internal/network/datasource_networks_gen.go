@@ -0,0 +1,190 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type NetworksDataSourceModel struct {
+	Project types.String `tfsdk:"project"`
+	Target  types.String `tfsdk:"target"`
+	Remote  types.String `tfsdk:"remote"`
+
+	// Filters.
+	Config types.Map    `tfsdk:"config"`
+	Status types.String `tfsdk:"status"`
+
+	Networks types.Map `tfsdk:"networks"`
+}
+
+type NetworksDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewNetworksDataSource() datasource.DataSource {
+	return &NetworksDataSource{}
+}
+
+func (d *NetworksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_networks", req.ProviderTypeName)
+}
+
+func (d *NetworksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"target": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"config": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"status": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"networks": schema.MapAttribute{
+				Computed:    true,
+				ElementType: getNetworksElemType(),
+			},
+		},
+	}
+}
+
+func (d *NetworksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *NetworksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state NetworksDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := state.Target.ValueString()
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, state.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	networks, err := server.GetNetworks()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to retrieve networks", err.Error())
+		return
+	}
+
+	elemType := getNetworksElemType()
+	elems := make(map[string]attr.Value, len(networks))
+
+	for _, network := range networks {
+		if !common.MatchConfig(network.Config, configFilter) {
+			continue
+		}
+
+		if !common.MatchFilter(state.Status, network.Status) {
+			continue
+		}
+
+		attrs := map[string]attr.Value{
+			"name":        types.StringValue(network.Name),
+			"description": types.StringValue(network.Description),
+			"status":      types.StringValue(network.Status),
+			"type":        types.StringValue(network.Type),
+			"managed":     types.BoolValue(network.Managed),
+		}
+
+		attrs["config"], diags = common.ToConfigMapType(ctx, common.ToNullableConfig(network.Config), types.MapNull(types.StringType))
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		attrs["locations"], diags = types.ListValueFrom(ctx, types.StringType, network.Locations)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		key := network.Name
+		elems[key], diags = types.ObjectValue(elemType.AttrTypes, attrs)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	state.Networks, diags = types.MapValue(elemType, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getNetworksElemType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":        types.StringType,
+			"description": types.StringType,
+			"config":      types.MapType{ElemType: types.StringType},
+			"status":      types.StringType,
+			"locations":   types.ListType{ElemType: types.StringType},
+			"type":        types.StringType,
+			"managed":     types.BoolType,
+		},
+	}
+}
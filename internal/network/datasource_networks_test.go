@@ -0,0 +1,110 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccNetworksDataSource_filters(t *testing.T) {
+	team := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworksDataSource_filters(team),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.%", "2"),
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.eth1.name", "eth1"),
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.eth1.type", "bridge"),
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.eth1.managed", "true"),
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.eth1.description", "Web network"),
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.eth1.config.user.team", team),
+					resource.TestCheckResourceAttr("data.incus_networks.team", "networks.eth2.name", "eth2"),
+					resource.TestCheckResourceAttr("data.incus_networks.web", "networks.%", "1"),
+					resource.TestCheckResourceAttr("data.incus_networks.web", "networks.eth1.config.user.role", "web"),
+					resource.TestCheckResourceAttr("data.incus_networks.created", "networks.%", "2"),
+					resource.TestCheckResourceAttr("data.incus_networks.errored", "networks.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccNetworksDataSource_filters(team string) string {
+	return fmt.Sprintf(`
+resource "incus_network" "eth1" {
+  name        = "eth1"
+  description = "Web network"
+
+  config = {
+    "user.team" = "%[1]s"
+    "user.role" = "web"
+  }
+}
+
+resource "incus_network" "eth2" {
+  name = "eth2"
+
+  config = {
+    "user.team" = "%[1]s"
+    "user.role" = "db"
+  }
+}
+
+data "incus_networks" "team" {
+  config = {
+    "user.team" = "%[1]s"
+  }
+
+  depends_on = [
+    incus_network.eth1,
+    incus_network.eth2,
+  ]
+}
+
+data "incus_networks" "web" {
+  config = {
+    "user.team" = "%[1]s"
+    "user.role" = "web"
+  }
+
+  depends_on = [
+    incus_network.eth1,
+    incus_network.eth2,
+  ]
+}
+
+data "incus_networks" "created" {
+  status = "CREATED"
+
+  config = {
+    "user.team" = "%[1]s"
+  }
+
+  depends_on = [
+    incus_network.eth1,
+    incus_network.eth2,
+  ]
+}
+
+data "incus_networks" "errored" {
+  status = "errored"
+
+  config = {
+    "user.team" = "%[1]s"
+  }
+
+  depends_on = [
+    incus_network.eth1,
+    incus_network.eth2,
+  ]
+}
+`, team)
+}
@@ -0,0 +1,144 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// NetworkListModel contains the filters of the network list resource.
+type NetworkListModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+	Type    types.String `tfsdk:"type"`
+	Config  types.Map    `tfsdk:"config"`
+}
+
+// NetworkListResource lists managed Incus networks.
+type NetworkListResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewNetworkListResource returns a new network list resource.
+func NewNetworkListResource() list.ListResource {
+	return &NetworkListResource{}
+}
+
+func (r NetworkListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_network", req.ProviderTypeName)
+}
+
+func (r NetworkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := common.ListSchemaAttributes()
+
+	attributes["type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list networks of the given type, such as bridge or ovn.",
+	}
+
+	attributes["config"] = schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Only list networks whose configuration contains all the given key/value pairs.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (r *NetworkListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r NetworkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter NetworkListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, filter.Config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	remote := filter.Remote.ValueString()
+	project := filter.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	networks, err := server.GetNetworks()
+	if err != nil {
+		diags.AddError("Failed to retrieve networks", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	networkResource := NetworkResource{provider: r.provider}
+	syncState := func(ctx context.Context, state *tfsdk.State, m NetworkModel) diag.Diagnostics {
+		return networkResource.SyncState(ctx, state, server, m)
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, network := range networks {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			// Only managed networks can be imported.
+			if !network.Managed {
+				continue
+			}
+
+			if !common.MatchFilter(filter.Type, network.Type) || !common.MatchConfig(network.Config, configFilter) {
+				continue
+			}
+
+			fields := map[string]string{
+				"name":    network.Name,
+				"project": project,
+				"remote":  remote,
+			}
+
+			result, ok := common.NewListResult(ctx, req, network.Name, fields, syncState)
+			if !ok {
+				continue
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
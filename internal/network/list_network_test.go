@@ -0,0 +1,72 @@
+package network_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccNetworkList_config(t *testing.T) {
+	team := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkList_resources(team),
+			},
+			{
+				Query:  true,
+				Config: testAccNetworkList_config(team),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("incus_network.team", 1),
+					querycheck.ExpectIdentity("incus_network.team", map[string]knownvalue.Check{
+						"name":    knownvalue.StringExact("eth1"),
+						"project": knownvalue.Null(),
+						"remote":  knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func testAccNetworkList_resources(team string) string {
+	return fmt.Sprintf(`
+resource "incus_network" "eth1" {
+  name = "eth1"
+
+  config = {
+    "user.team" = "%s"
+  }
+}
+`, team)
+}
+
+func testAccNetworkList_config(team string) string {
+	return fmt.Sprintf(`
+provider "incus" {}
+
+list "incus_network" "team" {
+  provider = incus
+
+  config {
+    type = "bridge"
+    config = {
+      "user.team" = "%s"
+    }
+  }
+}
+`, team)
+}
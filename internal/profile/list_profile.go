@@ -0,0 +1,139 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// ProfileListModel contains the filters of the profile list resource.
+type ProfileListModel struct {
+	Project types.String `tfsdk:"project"`
+	Remote  types.String `tfsdk:"remote"`
+	Config  types.Map    `tfsdk:"config"`
+}
+
+// ProfileListResource lists Incus profiles.
+type ProfileListResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewProfileListResource returns a new profile list resource.
+func NewProfileListResource() list.ListResource {
+	return &ProfileListResource{}
+}
+
+func (r ProfileListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_profile", req.ProviderTypeName)
+}
+
+func (r ProfileListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := common.ListSchemaAttributes()
+
+	attributes["config"] = schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Only list profiles whose configuration contains all the given key/value pairs.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (r *ProfileListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r ProfileListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter ProfileListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, filter.Config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	remote := filter.Remote.ValueString()
+	project := filter.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	profiles, err := server.GetProfiles()
+	if err != nil {
+		diags.AddError("Failed to retrieve profiles", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	// Profiles always belong to a project.
+	if project == "" {
+		project = api.ProjectDefaultName
+	}
+
+	profileResource := ProfileResource{provider: r.provider}
+	syncState := func(ctx context.Context, state *tfsdk.State, m ProfileModel) diag.Diagnostics {
+		return profileResource.SyncState(ctx, state, server, m)
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, profile := range profiles {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			if !common.MatchConfig(profile.Config, configFilter) {
+				continue
+			}
+
+			fields := map[string]string{
+				"name":    profile.Name,
+				"project": project,
+				"remote":  remote,
+			}
+
+			result, ok := common.NewListResult(ctx, req, profile.Name, fields, syncState)
+			if !ok {
+				continue
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
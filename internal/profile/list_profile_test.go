@@ -0,0 +1,77 @@
+package profile_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccProfileList_config(t *testing.T) {
+	profileName1 := petname.Generate(2, "-")
+	profileName2 := petname.Generate(2, "-")
+	team := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileList_resources(profileName1, profileName2, team),
+			},
+			{
+				Query:  true,
+				Config: testAccProfileList_config(team),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("incus_profile.team", 1),
+					querycheck.ExpectIdentity("incus_profile.team", map[string]knownvalue.Check{
+						"name":    knownvalue.StringExact(profileName1),
+						"project": knownvalue.StringExact("default"),
+						"remote":  knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func testAccProfileList_resources(profileName1, profileName2, team string) string {
+	return fmt.Sprintf(`
+resource "incus_profile" "profile1" {
+  name = "%s"
+
+  config = {
+    "user.team" = "%s"
+  }
+}
+
+resource "incus_profile" "profile2" {
+  name = "%s"
+}
+`, profileName1, team, profileName2)
+}
+
+func testAccProfileList_config(team string) string {
+	return fmt.Sprintf(`
+provider "incus" {}
+
+list "incus_profile" "team" {
+  provider = incus
+
+  config {
+    config = {
+      "user.team" = "%s"
+    }
+  }
+}
+`, team)
+}
@@ -50,6 +50,11 @@ func (r ProfileResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = fmt.Sprintf("%s_profile", req.ProviderTypeName)
 }
 
+// IdentitySchema for profile resource.
+func (r ProfileResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = common.IdentitySchema("name")
+}
+
 // Schema for profile resource.
 func (r ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -241,6 +246,7 @@ func (r ProfileResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -263,6 +269,11 @@ func (r ProfileResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -315,6 +326,7 @@ func (r ProfileResource) Update(ctx context.Context, req resource.UpdateRequest,
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -352,9 +364,9 @@ func (r ProfileResource) ImportState(ctx context.Context, req resource.ImportSta
 		RequiredFields: []string{"name"},
 	}
 
-	fields, diag := meta.ParseImportID(req.ID)
-	if diag != nil {
-		resp.Diagnostics.Append(diag)
+	fields, diags := meta.ParseImportRequest(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -472,6 +484,7 @@ func (r ProfileResource) importAndUpdateDefaultProfile(ctx context.Context, plan
 	// 3. Sync Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r ProfileResource) resetDefaultProfile(ctx context.Context, plan ProfileModel, resp *resource.DeleteResponse) {
@@ -6,6 +6,7 @@ import (
 
 	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 
 	"github.com/lxc/terraform-provider-incus/internal/acctest"
 )
@@ -257,6 +258,29 @@ func TestAccProfile_importBasic(t *testing.T) {
 	})
 }
 
+func TestAccProfile_importIdentity(t *testing.T) {
+	profileName := petname.Generate(2, "-")
+	resourceName := "incus_profile.profile1"
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfile_config(profileName),
+			},
+			{
+				ResourceName:    resourceName,
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
 func TestAccProfile_importConfig(t *testing.T) {
 	profileName := petname.Generate(2, "-")
 	resourceName := "incus_profile.profile1"
@@ -1,9 +1,11 @@
 package config
 
 import (
+	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -31,8 +33,44 @@ type IncusProviderRemoteConfig struct {
 	CredentialsHelper  string
 	AuthenticationType string
 	Token              string
+	ClientCertificate  string
+	ClientKey          string
+	ServerCertificate  string
 	Public             bool
 	Bootstrapped       bool
+
+	// MaxConcurrentOperations limits the number of operations started
+	// concurrently on the remote. A value of 0 means unlimited.
+	MaxConcurrentOperations int
+
+	// MaxConcurrentImageDownloads limits the number of operations that may
+	// download an image concurrently on the remote. A value of 0 means unlimited.
+	MaxConcurrentImageDownloads int
+
+	// ProxyURL is the proxy used to reach the remote. If empty, the proxy
+	// is taken from the environment.
+	ProxyURL string
+
+	// NoProxy lists the hosts, domains and networks that are not reached
+	// through the proxy, in the format of the NO_PROXY environment variable.
+	NoProxy string
+
+	// CACertificate is the PEM-encoded CA certificate used to verify the
+	// remote's certificate.
+	CACertificate string
+
+	// InsecureSkipVerify disables the verification of the remote's certificate.
+	InsecureSkipVerify bool
+
+	// SSH is the SSH transport used to reach the remote. If nil, the
+	// remote is connected to directly.
+	SSH *IncusProviderSSHConfig
+}
+
+// hasInlineCertificates returns true if any of the remote's TLS certificates
+// are provided inline rather than read from the Incus config directory.
+func (r IncusProviderRemoteConfig) hasInlineCertificates() bool {
+	return r.ClientCertificate != "" || r.ClientKey != "" || r.ServerCertificate != ""
 }
 
 // IncusProviderConfig contains the Provider configuration and initialized
@@ -61,6 +99,22 @@ type IncusProviderConfig struct {
 	// connected and authenticated to each defined Incus server/remote.
 	servers map[string]incus.Server
 
+	// limiters is a map of operation limiters for the Incus remotes which
+	// limit the number of concurrent operations.
+	limiters map[string]*operationLimiter
+
+	// retryPolicy defines how requests to the remotes are retried when
+	// they fail with a transient error.
+	retryPolicy RetryPolicy
+
+	// sshTunnels is a map of SSH connections, shared by the remotes that
+	// are reached through the same SSH host.
+	sshTunnels map[string]*sshTunnel
+
+	// cleanups are run when the provider is closed, e.g. to stop the local
+	// unix sockets forwarded to remotes reached through SSH.
+	cleanups []func()
+
 	// This is a mutex used to handle concurrent reads/writes.
 	mux sync.RWMutex
 
@@ -80,6 +134,27 @@ func NewIncusProvider(incusConfig *incus_config.Config, acceptServerCert bool) *
 		incusConfig:             incusConfig,
 		remotes:                 make(map[string]IncusProviderRemoteConfig),
 		servers:                 make(map[string]incus.Server),
+		limiters:                make(map[string]*operationLimiter),
+		sshTunnels:              make(map[string]*sshTunnel),
+	}
+}
+
+// Close releases the resources held by the provider, such as the local unix
+// sockets and the connections of remotes reached through SSH.
+func (p *IncusProviderConfig) Close() {
+	p.mux.Lock()
+	cleanups := p.cleanups
+	tunnels := p.sshTunnels
+	p.cleanups = nil
+	p.sshTunnels = make(map[string]*sshTunnel)
+	p.mux.Unlock()
+
+	for _, cleanup := range cleanups {
+		cleanup()
+	}
+
+	for _, tunnel := range tunnels {
+		tunnel.close()
 	}
 }
 
@@ -108,6 +183,15 @@ func (p *IncusProviderConfig) InstanceServer(remoteName string, project string,
 		return nil, fmt.Errorf("Remote %q (%s) is not an InstanceServer", remoteName, connInfo.Protocol)
 	}
 
+	if remoteName == "" {
+		remoteName = p.incusConfig.DefaultRemote
+	}
+
+	limiter := p.limiters[remoteName]
+	if limiter != nil {
+		instServer = &limitedInstanceServer{InstanceServer: instServer, limiter: limiter}
+	}
+
 	instServer = instServer.UseProject(project)
 	instServer = instServer.UseTarget(target)
 
@@ -158,8 +242,24 @@ func (p *IncusProviderConfig) server(remoteName string) (incus.Server, error) {
 		return server, nil
 	}
 
-	// If the Incus or Image Server is not already configured, create a new one.
+	// Remotes reached through SSH are connected to directly, as neither
+	// their addresses nor their unix socket are reachable from the client.
 	remote := p.remote(remoteName)
+	if remote != nil && remote.SSH != nil {
+		instServer, err := p.createSSHInstanceServer(*remote)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to create Incus Server client for remote %q: %v", remoteName, err)
+		}
+
+		err = verifyIncusServerVersion(instServer)
+		if err != nil {
+			return nil, fmt.Errorf("Remote %q: %v", remoteName, err)
+		}
+
+		return p.cacheServer(remoteName, instServer)
+	}
+
+	// If the Incus or Image Server is not already configured, create a new one.
 	if remote != nil && !remote.Bootstrapped {
 		switch remote.Protocol {
 		case "oci":
@@ -221,7 +321,17 @@ func (p *IncusProviderConfig) server(remoteName string) (incus.Server, error) {
 		}
 	}
 
-	// Add the server to the incusServer map (cache).
+	return p.cacheServer(remoteName, server)
+}
+
+// cacheServer applies the retry policy to the given server and adds it to
+// the servers map (cache).
+func (p *IncusProviderConfig) cacheServer(remoteName string, server incus.Server) (incus.Server, error) {
+	err := p.applyRetryPolicy(server)
+	if err != nil {
+		return nil, fmt.Errorf("Remote %q: %v", remoteName, err)
+	}
+
 	p.mux.Lock()
 	defer p.mux.Unlock()
 
@@ -230,6 +340,26 @@ func (p *IncusProviderConfig) server(remoteName string) (incus.Server, error) {
 	return server, nil
 }
 
+// applyRetryPolicy wraps the HTTP transport of the given server, so that all
+// requests sent to it are retried according to the provider's retry policy.
+func (p *IncusProviderConfig) applyRetryPolicy(server incus.Server) error {
+	p.mux.RLock()
+	policy := p.retryPolicy
+	p.mux.RUnlock()
+
+	if policy.MaxAttempts <= 1 {
+		return nil
+	}
+
+	httpClient, err := server.GetHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	httpClient.Transport = NewRetryTransport(httpClient.Transport, policy)
+	return nil
+}
+
 // createIncusServerClient will create an Incus client for a given remote.
 // The client is then stored in the incusProvider.Config collection of clients.
 func (p *IncusProviderConfig) createIncusServerClient(remote IncusProviderRemoteConfig) error {
@@ -256,7 +386,7 @@ func (p *IncusProviderConfig) createIncusServerClient(remote IncusProviderRemote
 		certPath := p.incusConfig.ServerCertPath(remote.Name)
 		p.mux.RUnlock()
 
-		if !incus_shared.PathExists(certPath) {
+		if remote.requiresServerCertificate() && !incus_shared.PathExists(certPath) {
 			// Try to obtain an early connection to the remote server.
 			// If it succeeds, then either the certificates between
 			// the remote and the client have already been exchanged
@@ -316,13 +446,26 @@ func (p *IncusProviderConfig) createImageServerRemote(remote IncusProviderRemote
 func (p *IncusProviderConfig) fetchIncusServerCertificate(remoteName string) error {
 	incusRemote := p.getIncusConfigRemote(remoteName)
 
+	var proxy func(*http.Request) (*url.URL, error)
+	remote := p.remote(remoteName)
+	if remote != nil {
+		proxy = remote.proxyFunc()
+	}
+
 	if len(incusRemote.Addrs) == 0 {
 		return fmt.Errorf("Remote %q does not have an address", remoteName)
 	}
 
 	var certificateErrs []error
 	for _, remoteAddress := range incusRemote.Addrs {
-		certificate, err := incus_tls.GetRemoteCertificate(remoteAddress, "terraform-provider-incus/1.0")
+		var certificate *x509.Certificate
+		var err error
+		if proxy != nil {
+			certificate, err = getRemoteCertificate(remoteAddress, "terraform-provider-incus/1.0", proxy)
+		} else {
+			certificate, err = incus_tls.GetRemoteCertificate(remoteAddress, "terraform-provider-incus/1.0")
+		}
+
 		if err != nil {
 			certificateErrs = append(certificateErrs, fmt.Errorf("%s: %w", remoteAddress, err))
 			continue
@@ -547,9 +690,25 @@ func (p *IncusProviderConfig) SetRemote(remote IncusProviderRemoteConfig, isDefa
 		p.incusConfig.DefaultRemote = remote.Name
 	}
 
+	_, ok := p.limiters[remote.Name]
+	if !ok {
+		limiter := newOperationLimiter(remote.MaxConcurrentOperations, remote.MaxConcurrentImageDownloads)
+		if limiter != nil {
+			p.limiters[remote.Name] = limiter
+		}
+	}
+
 	p.remotes[remote.Name] = remote
 }
 
+// SetRetryPolicy sets the policy used to retry requests to all remotes.
+func (p *IncusProviderConfig) SetRetryPolicy(policy RetryPolicy) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.retryPolicy = policy
+}
+
 // SelectRemote returns the specified remote name if it exists, or the default
 // remote name otherwise.
 func (p *IncusProviderConfig) SelectRemote(name string) string {
@@ -584,6 +743,18 @@ func (p *IncusProviderConfig) getIncusConfigInstanceServer(remoteName string) (i
 	p.mux.RLock()
 	defer p.mux.RUnlock()
 
+	remote, ok := p.remotes[remoteName]
+	incusRemote := p.incusConfig.Remotes[remoteName]
+	if ok && usesDirectConnection(remote, incusRemote) {
+		// The OIDC tokens are only loaded and stored by the Incus config,
+		// hence OIDC remotes can not be connected to directly.
+		if incusRemote.AuthType == incus_api.AuthenticationMethodOIDC {
+			return nil, fmt.Errorf("Remote %q: Inline certificates and transport settings are not supported with OIDC authentication", remoteName)
+		}
+
+		return p.connectIncusServer(remote, incusRemote)
+	}
+
 	server, err := p.incusConfig.GetInstanceServer(remoteName)
 	if err != nil {
 		return nil, err
@@ -591,7 +762,6 @@ func (p *IncusProviderConfig) getIncusConfigInstanceServer(remoteName string) (i
 
 	// When using OIDC authentication, store the returned token locally
 	// so the user only completes the device authorization flow once.
-	incusRemote := p.incusConfig.Remotes[remoteName]
 	if incusRemote.AuthType == incus_api.AuthenticationMethodOIDC {
 		p.incusConfig.SaveOIDCTokens()
 	}
@@ -599,10 +769,95 @@ func (p *IncusProviderConfig) getIncusConfigInstanceServer(remoteName string) (i
 	return server, nil
 }
 
+// usesDirectConnection returns true if the remote is connected to directly
+// rather than through the Incus config. This is the case for remotes with
+// inline certificates, so that the certificates never have to be written to
+// the config directory, and for remotes with their own proxy or TLS
+// verification settings.
+func usesDirectConnection(remote IncusProviderRemoteConfig, incusRemote incus_config.Remote) bool {
+	return (remote.hasInlineCertificates() || remote.hasTransportSettings()) && requiresRemoteCertificate(incusRemote.Addrs)
+}
+
+// connectIncusServer connects to the given remote using its inline TLS
+// certificates. Certificates that are not provided inline are read from the
+// Incus config directory, if present. The caller must hold the mutex.
+func (p *IncusProviderConfig) connectIncusServer(remote IncusProviderRemoteConfig, incusRemote incus_config.Remote) (incus.InstanceServer, error) {
+	args, err := p.connectionArgs(remote, incusRemote)
+	if err != nil {
+		return nil, err
+	}
+
+	var connectErrs []error
+	for _, address := range incusRemote.Addrs {
+		server, err := incus.ConnectIncus(address, args)
+		if err != nil {
+			connectErrs = append(connectErrs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+
+		return server, nil
+	}
+
+	return nil, errors.Join(connectErrs...)
+}
+
+// connectionArgs returns the arguments to connect to the given remote over
+// HTTPS. The caller must hold the mutex.
+func (p *IncusProviderConfig) connectionArgs(remote IncusProviderRemoteConfig, incusRemote incus_config.Remote) (*incus.ConnectionArgs, error) {
+	args := incus.ConnectionArgs{
+		TLSClientCert: remote.ClientCertificate,
+		TLSClientKey:  remote.ClientKey,
+		TLSServerCert: remote.ServerCertificate,
+		UserAgent:     p.incusConfig.UserAgent,
+		AuthType:      incusRemote.AuthType,
+	}
+
+	remote.applyTransportSettings(&args)
+
+	if args.TLSClientCert == "" && args.TLSClientKey == "" {
+		certPath := p.incusConfig.ConfigPath("client.crt")
+		keyPath := p.incusConfig.ConfigPath("client.key")
+		if incus_shared.PathExists(certPath) && incus_shared.PathExists(keyPath) {
+			cert, err := os.ReadFile(certPath)
+			if err != nil {
+				return nil, err
+			}
+
+			key, err := os.ReadFile(keyPath)
+			if err != nil {
+				return nil, err
+			}
+
+			args.TLSClientCert = string(cert)
+			args.TLSClientKey = string(key)
+		}
+	}
+
+	if args.TLSServerCert == "" && args.TLSCA == "" && !args.InsecureSkipVerify {
+		certPath := p.incusConfig.ServerCertPath(remote.Name)
+		if incus_shared.PathExists(certPath) {
+			cert, err := os.ReadFile(certPath)
+			if err != nil {
+				return nil, err
+			}
+
+			args.TLSServerCert = string(cert)
+		}
+	}
+
+	return &args, nil
+}
+
 // getIncusConfigImageServer will retrieve an IncusImageServer client
 // in a conncurrent-safe way.
 func (p *IncusProviderConfig) getIncusConfigImageServer(remoteName string) (incus.ImageServer, error) {
 	p.mux.RLock()
 	defer p.mux.RUnlock()
+
+	remote, ok := p.remotes[remoteName]
+	if ok && remote.hasTransportSettings() {
+		return p.connectImageServer(remote, p.incusConfig.Remotes[remoteName])
+	}
+
 	return p.incusConfig.GetImageServer(remoteName)
 }
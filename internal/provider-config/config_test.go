@@ -1,9 +1,14 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 
+	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
+
 	config "github.com/lxc/terraform-provider-incus/internal/provider-config"
 )
 
@@ -53,3 +58,229 @@ func TestRemoteAddresses(t *testing.T) {
 		})
 	}
 }
+
+func TestHasInlineCertificates(t *testing.T) {
+	tests := []struct {
+		name   string
+		remote config.IncusProviderRemoteConfig
+		want   bool
+	}{
+		{name: "none"},
+		{name: "client certificate only", remote: config.IncusProviderRemoteConfig{ClientCertificate: "cert"}, want: true},
+		{name: "client key only", remote: config.IncusProviderRemoteConfig{ClientKey: "key"}, want: true},
+		{name: "server certificate only", remote: config.IncusProviderRemoteConfig{ServerCertificate: "cert"}, want: true},
+		{name: "all", remote: config.IncusProviderRemoteConfig{ClientCertificate: "cert", ClientKey: "key", ServerCertificate: "cert"}, want: true},
+		{name: "ca certificate", remote: config.IncusProviderRemoteConfig{CACertificate: "ca"}},
+		{name: "token", remote: config.IncusProviderRemoteConfig{Token: "token"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.HasInlineCertificates(tt.remote)
+			if got != tt.want {
+				t.Fatalf("hasInlineCertificates() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiresServerCertificate(t *testing.T) {
+	tests := []struct {
+		name   string
+		remote config.IncusProviderRemoteConfig
+		want   bool
+	}{
+		{name: "none", want: true},
+		{name: "client certificate and key", remote: config.IncusProviderRemoteConfig{ClientCertificate: "cert", ClientKey: "key"}, want: true},
+		{name: "server certificate", remote: config.IncusProviderRemoteConfig{ServerCertificate: "cert"}},
+		{name: "ca certificate", remote: config.IncusProviderRemoteConfig{CACertificate: "ca"}},
+		{name: "insecure skip verify", remote: config.IncusProviderRemoteConfig{InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.RequiresServerCertificate(tt.remote)
+			if got != tt.want {
+				t.Fatalf("requiresServerCertificate() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsesDirectConnection(t *testing.T) {
+	https := incus_config.Remote{Addrs: []string{"https://10.0.0.1:8443"}}
+	unix := incus_config.Remote{Addrs: []string{"unix://"}}
+
+	tests := []struct {
+		name        string
+		remote      config.IncusProviderRemoteConfig
+		incusRemote incus_config.Remote
+		want        bool
+	}{
+		{name: "none", incusRemote: https},
+		{name: "inline certificates", remote: config.IncusProviderRemoteConfig{ServerCertificate: "cert"}, incusRemote: https, want: true},
+		{name: "transport settings", remote: config.IncusProviderRemoteConfig{ProxyURL: "http://proxy:3128"}, incusRemote: https, want: true},
+		{name: "unix socket", remote: config.IncusProviderRemoteConfig{ServerCertificate: "cert"}, incusRemote: unix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.UsesDirectConnection(tt.remote, tt.incusRemote)
+			if got != tt.want {
+				t.Fatalf("usesDirectConnection() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncusConfigInstanceServerOIDC(t *testing.T) {
+	// OIDC remotes are never connected to directly, as the connection
+	// would lack the OIDC tokens stored in the Incus config.
+	incusConfig := &incus_config.Config{
+		ConfigDir: t.TempDir(),
+		Remotes: map[string]incus_config.Remote{
+			"remote1": {Addrs: []string{"https://10.0.0.1:8443"}, AuthType: "oidc", Protocol: "incus"},
+		},
+	}
+
+	p := config.NewIncusProvider(incusConfig, false)
+	p.SetRemote(config.IncusProviderRemoteConfig{Name: "remote1", AuthenticationType: "oidc", ServerCertificate: "inline-server-cert"}, false)
+
+	_, err := p.IncusConfigInstanceServer("remote1")
+	if err == nil || !strings.Contains(err.Error(), "not supported with OIDC authentication") {
+		t.Fatalf("Expected OIDC error, got %v", err)
+	}
+}
+
+func TestConnectionArgs(t *testing.T) {
+	configDir := t.TempDir()
+	writeConfigFile(t, configDir, "client.crt", "disk-client-cert")
+	writeConfigFile(t, configDir, "client.key", "disk-client-key")
+	writeConfigFile(t, configDir, "servercerts/remote1.crt", "disk-server-cert-1")
+	writeConfigFile(t, configDir, "servercerts/remote2.crt", "disk-server-cert-2")
+
+	tests := []struct {
+		name           string
+		remote         config.IncusProviderRemoteConfig
+		wantClientCert string
+		wantClientKey  string
+		wantServerCert string
+	}{
+		{
+			name:           "on-disk certificates",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote1"},
+			wantClientCert: "disk-client-cert",
+			wantClientKey:  "disk-client-key",
+			wantServerCert: "disk-server-cert-1",
+		},
+		{
+			name:           "inline certificates take precedence",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote1", ClientCertificate: "inline-client-cert", ClientKey: "inline-client-key", ServerCertificate: "inline-server-cert"},
+			wantClientCert: "inline-client-cert",
+			wantClientKey:  "inline-client-key",
+			wantServerCert: "inline-server-cert",
+		},
+		{
+			name:           "inline client identity with on-disk server certificate",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote1", ClientCertificate: "inline-client-cert", ClientKey: "inline-client-key"},
+			wantClientCert: "inline-client-cert",
+			wantClientKey:  "inline-client-key",
+			wantServerCert: "disk-server-cert-1",
+		},
+		{
+			name:           "inline server certificate with on-disk client identity",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote1", ServerCertificate: "inline-server-cert"},
+			wantClientCert: "disk-client-cert",
+			wantClientKey:  "disk-client-key",
+			wantServerCert: "inline-server-cert",
+		},
+		{
+			// A partial inline client identity is never completed from
+			// disk, as the key would not match the certificate.
+			name:           "partial inline client certificate",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote1", ClientCertificate: "inline-client-cert"},
+			wantClientCert: "inline-client-cert",
+			wantServerCert: "disk-server-cert-1",
+		},
+		{
+			name:           "partial inline client key",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote1", ClientKey: "inline-client-key"},
+			wantClientKey:  "inline-client-key",
+			wantServerCert: "disk-server-cert-1",
+		},
+		{
+			name:           "per-remote server certificate",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote2"},
+			wantClientCert: "disk-client-cert",
+			wantClientKey:  "disk-client-key",
+			wantServerCert: "disk-server-cert-2",
+		},
+		{
+			name:           "per-remote inline identity",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote2", ClientCertificate: "inline-client-cert-2", ClientKey: "inline-client-key-2"},
+			wantClientCert: "inline-client-cert-2",
+			wantClientKey:  "inline-client-key-2",
+			wantServerCert: "disk-server-cert-2",
+		},
+		{
+			name:           "unknown remote",
+			remote:         config.IncusProviderRemoteConfig{Name: "remote3"},
+			wantClientCert: "disk-client-cert",
+			wantClientKey:  "disk-client-key",
+		},
+	}
+
+	p := config.NewIncusProvider(&incus_config.Config{ConfigDir: configDir}, false)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := p.ConnectionArgs(tt.remote, incus_config.Remote{AuthType: "tls"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if args.TLSClientCert != tt.wantClientCert {
+				t.Fatalf("TLSClientCert = %q, want %q", args.TLSClientCert, tt.wantClientCert)
+			}
+
+			if args.TLSClientKey != tt.wantClientKey {
+				t.Fatalf("TLSClientKey = %q, want %q", args.TLSClientKey, tt.wantClientKey)
+			}
+
+			if args.TLSServerCert != tt.wantServerCert {
+				t.Fatalf("TLSServerCert = %q, want %q", args.TLSServerCert, tt.wantServerCert)
+			}
+
+			if args.AuthType != "tls" {
+				t.Fatalf("AuthType = %q, want %q", args.AuthType, "tls")
+			}
+		})
+	}
+
+	// Without on-disk certificates, only the inline ones are used.
+	p = config.NewIncusProvider(&incus_config.Config{ConfigDir: t.TempDir()}, false)
+
+	args, err := p.ConnectionArgs(config.IncusProviderRemoteConfig{Name: "remote1"}, incus_config.Remote{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if args.TLSClientCert != "" || args.TLSClientKey != "" || args.TLSServerCert != "" {
+		t.Fatalf("Expected no certificates, got %#v", args)
+	}
+}
+
+func writeConfigFile(t *testing.T, configDir string, name string, content string) {
+	t.Helper()
+
+	path := filepath.Join(configDir, filepath.FromSlash(name))
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
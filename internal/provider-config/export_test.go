@@ -0,0 +1,26 @@
+package config
+
+import (
+	incus "github.com/lxc/incus/v7/client"
+	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
+)
+
+// Unexported functions used by the tests of the config_test package.
+var (
+	HasInlineCertificates     = IncusProviderRemoteConfig.hasInlineCertificates
+	RequiresServerCertificate = IncusProviderRemoteConfig.requiresServerCertificate
+	UsesDirectConnection      = usesDirectConnection
+)
+
+// ConnectionArgs returns the arguments to connect to the given remote.
+func (p *IncusProviderConfig) ConnectionArgs(remote IncusProviderRemoteConfig, incusRemote incus_config.Remote) (*incus.ConnectionArgs, error) {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+
+	return p.connectionArgs(remote, incusRemote)
+}
+
+// IncusConfigInstanceServer returns the client of the given remote.
+func (p *IncusProviderConfig) IncusConfigInstanceServer(remoteName string) (incus.InstanceServer, error) {
+	return p.getIncusConfigInstanceServer(remoteName)
+}
@@ -0,0 +1,274 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// defaultAcquireTimeout is the maximum time an operation waits for a free
+// slot of the remote's limiter before failing.
+const defaultAcquireTimeout = 30 * time.Minute
+
+// operationLimiter limits the number of concurrent operations and image
+// downloads started by the provider on a single remote.
+type operationLimiter struct {
+	operations     chan struct{}
+	imageDownloads chan struct{}
+
+	// acquireTimeout bounds the wait for a free slot, as the InstanceServer
+	// methods starting operations do not take a context.
+	acquireTimeout time.Duration
+}
+
+// newOperationLimiter returns a limiter for the given limits. A limit lower
+// or equal to 0 means unlimited. Nil is returned if neither is limited.
+func newOperationLimiter(maxOperations int, maxImageDownloads int) *operationLimiter {
+	if maxOperations <= 0 && maxImageDownloads <= 0 {
+		return nil
+	}
+
+	limiter := &operationLimiter{acquireTimeout: defaultAcquireTimeout}
+
+	if maxOperations > 0 {
+		limiter.operations = make(chan struct{}, maxOperations)
+	}
+
+	if maxImageDownloads > 0 {
+		limiter.imageDownloads = make(chan struct{}, maxImageDownloads)
+	}
+
+	return limiter
+}
+
+// acquire blocks until a slot is available in the given semaphore or the
+// context is done, and returns a function releasing the slot. The returned
+// function is safe to call repeatedly.
+func acquire(ctx context.Context, semaphore chan struct{}) (func(), error) {
+	if semaphore == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case semaphore <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-semaphore })
+	}, nil
+}
+
+// acquireOperation acquires an operation slot and, if the operation may
+// download an image, an image download slot. Slots are always acquired in the
+// same order to prevent deadlocks.
+func (l *operationLimiter) acquireOperation(ctx context.Context, imageDownload bool) (func(), error) {
+	releaseOperation, err := acquire(ctx, l.operations)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to wait for a free operation slot: %w", err)
+	}
+
+	if !imageDownload {
+		return releaseOperation, nil
+	}
+
+	releaseImageDownload, err := acquire(ctx, l.imageDownloads)
+	if err != nil {
+		releaseOperation()
+		return nil, fmt.Errorf("Failed to wait for a free image download slot: %w", err)
+	}
+
+	return func() {
+		releaseImageDownload()
+		releaseOperation()
+	}, nil
+}
+
+// limitedInstanceServer wraps an InstanceServer, holding a slot of the
+// remote's limiter from the creation of an operation until it completes.
+type limitedInstanceServer struct {
+	incus.InstanceServer
+
+	limiter *operationLimiter
+}
+
+// unwrapInstanceServer returns the underlying InstanceServer. Source servers
+// passed to copy calls are unwrapped, so that the migration they start on
+// the source does not acquire a second slot.
+func unwrapInstanceServer(server incus.InstanceServer) incus.InstanceServer {
+	limited, ok := server.(*limitedInstanceServer)
+	if ok {
+		return limited.InstanceServer
+	}
+
+	return server
+}
+
+// acquireOperation acquires a slot of the limiter, waiting at most for the
+// limiter's acquire timeout.
+func (s *limitedInstanceServer) acquireOperation(imageDownload bool) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.limiter.acquireTimeout)
+	defer cancel()
+
+	return s.limiter.acquireOperation(ctx, imageDownload)
+}
+
+// UseProject implements incus.InstanceServer.
+func (s *limitedInstanceServer) UseProject(name string) incus.InstanceServer {
+	return &limitedInstanceServer{InstanceServer: s.InstanceServer.UseProject(name), limiter: s.limiter}
+}
+
+// UseTarget implements incus.InstanceServer.
+func (s *limitedInstanceServer) UseTarget(name string) incus.InstanceServer {
+	return &limitedInstanceServer{InstanceServer: s.InstanceServer.UseTarget(name), limiter: s.limiter}
+}
+
+// CreateInstance implements incus.InstanceServer.
+func (s *limitedInstanceServer) CreateInstance(instance api.InstancesPost) (incus.Operation, error) {
+	release, err := s.acquireOperation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.CreateInstance(instance)
+	return limitOperation(op, err, release)
+}
+
+// CreateInstanceFromImage implements incus.InstanceServer.
+func (s *limitedInstanceServer) CreateInstanceFromImage(source incus.ImageServer, image api.Image, req api.InstancesPost) (incus.RemoteOperation, error) {
+	release, err := s.acquireOperation(true)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.CreateInstanceFromImage(source, image, req)
+	return limitRemoteOperation(op, err, release)
+}
+
+// CreateInstanceFromBackup implements incus.InstanceServer.
+func (s *limitedInstanceServer) CreateInstanceFromBackup(args incus.InstanceBackupArgs) (incus.Operation, error) {
+	release, err := s.acquireOperation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.CreateInstanceFromBackup(args)
+	return limitOperation(op, err, release)
+}
+
+// CopyInstance implements incus.InstanceServer.
+func (s *limitedInstanceServer) CopyInstance(source incus.InstanceServer, instance api.Instance, args *incus.InstanceCopyArgs) (incus.RemoteOperation, error) {
+	release, err := s.acquireOperation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.CopyInstance(unwrapInstanceServer(source), instance, args)
+	return limitRemoteOperation(op, err, release)
+}
+
+// CopyInstanceSnapshot implements incus.InstanceServer.
+func (s *limitedInstanceServer) CopyInstanceSnapshot(source incus.InstanceServer, instanceName string, snapshot api.InstanceSnapshot, args *incus.InstanceSnapshotCopyArgs) (incus.RemoteOperation, error) {
+	release, err := s.acquireOperation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.CopyInstanceSnapshot(unwrapInstanceServer(source), instanceName, snapshot, args)
+	return limitRemoteOperation(op, err, release)
+}
+
+// MigrateInstance implements incus.InstanceServer.
+func (s *limitedInstanceServer) MigrateInstance(name string, instance api.InstancePost) (incus.Operation, error) {
+	release, err := s.acquireOperation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.MigrateInstance(name, instance)
+	return limitOperation(op, err, release)
+}
+
+// ExecInstance implements incus.InstanceServer.
+func (s *limitedInstanceServer) ExecInstance(instanceName string, exec api.InstanceExecPost, args *incus.InstanceExecArgs) (incus.Operation, error) {
+	release, err := s.acquireOperation(false)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.ExecInstance(instanceName, exec, args)
+	return limitOperation(op, err, release)
+}
+
+// CopyImage implements incus.InstanceServer.
+func (s *limitedInstanceServer) CopyImage(source incus.ImageServer, image api.Image, args *incus.ImageCopyArgs) (incus.RemoteOperation, error) {
+	release, err := s.acquireOperation(true)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := s.InstanceServer.CopyImage(source, image, args)
+	return limitRemoteOperation(op, err, release)
+}
+
+// limitedOperation releases its limiter slot once the operation completes.
+type limitedOperation struct {
+	incus.Operation
+
+	release func()
+}
+
+func limitOperation(op incus.Operation, err error, release func()) (incus.Operation, error) {
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	return &limitedOperation{Operation: op, release: release}, nil
+}
+
+// Wait implements incus.Operation.
+func (op *limitedOperation) Wait() error {
+	defer op.release()
+	return op.Operation.Wait()
+}
+
+// WaitContext implements incus.Operation.
+func (op *limitedOperation) WaitContext(ctx context.Context) error {
+	defer op.release()
+	return op.Operation.WaitContext(ctx)
+}
+
+// limitedRemoteOperation releases its limiter slot once the operation completes.
+type limitedRemoteOperation struct {
+	incus.RemoteOperation
+
+	release func()
+}
+
+func limitRemoteOperation(op incus.RemoteOperation, err error, release func()) (incus.RemoteOperation, error) {
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	return &limitedRemoteOperation{RemoteOperation: op, release: release}, nil
+}
+
+// Wait implements incus.RemoteOperation.
+func (op *limitedRemoteOperation) Wait() error {
+	defer op.release()
+	return op.RemoteOperation.Wait()
+}
+
+// WaitContext implements incus.RemoteOperation.
+func (op *limitedRemoteOperation) WaitContext(ctx context.Context) error {
+	defer op.release()
+	return op.RemoteOperation.WaitContext(ctx)
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+)
+
+// fakeInstanceServer returns the given operation and error when an instance
+// is created.
+type fakeInstanceServer struct {
+	incus.InstanceServer
+
+	op  incus.Operation
+	err error
+}
+
+func (s *fakeInstanceServer) CreateInstance(_ api.InstancesPost) (incus.Operation, error) {
+	return s.op, s.err
+}
+
+// fakeOperation completes immediately.
+type fakeOperation struct {
+	incus.Operation
+}
+
+func (op *fakeOperation) Wait() error {
+	return nil
+}
+
+func (op *fakeOperation) WaitContext(_ context.Context) error {
+	return nil
+}
+
+func TestNewOperationLimiter_unlimited(t *testing.T) {
+	if newOperationLimiter(0, 0) != nil {
+		t.Fatal("Expected no limiter without limits")
+	}
+
+	if newOperationLimiter(-1, -1) != nil {
+		t.Fatal("Expected no limiter with negative limits")
+	}
+
+	limiter := newOperationLimiter(0, 1)
+	if limiter.operations != nil {
+		t.Fatal("Expected operations to be unlimited")
+	}
+
+	// Unlimited slots never block.
+	for range 10 {
+		_, err := limiter.acquireOperation(context.Background(), false)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+}
+
+func TestOperationLimiter_acquireOperation(t *testing.T) {
+	limiter := newOperationLimiter(1, 1)
+
+	release, err := limiter.acquireOperation(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(limiter.operations) != 1 || len(limiter.imageDownloads) != 1 {
+		t.Fatal("Expected both an operation and an image download slot to be held")
+	}
+
+	// A second operation waits until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.acquireOperation(ctx, false)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// Releasing is safe to call repeatedly.
+	release()
+	release()
+
+	if len(limiter.operations) != 0 || len(limiter.imageDownloads) != 0 {
+		t.Fatal("Expected all slots to be released")
+	}
+}
+
+func TestOperationLimiter_acquireOperationOrder(t *testing.T) {
+	limiter := newOperationLimiter(1, 1)
+
+	release, err := limiter.acquireOperation(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The operation slot is acquired first, so an image download waiting
+	// for it does not hold the image download slot.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.acquireOperation(ctx, true)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if len(limiter.imageDownloads) != 0 {
+		t.Fatal("Expected the image download slot to be free")
+	}
+
+	release()
+
+	// An operation waiting for the image download slot releases its
+	// operation slot when it gives up.
+	releaseImageDownload, err := acquire(context.Background(), limiter.imageDownloads)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	defer releaseImageDownload()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.acquireOperation(ctx, true)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if len(limiter.operations) != 0 {
+		t.Fatal("Expected the operation slot to be released")
+	}
+}
+
+func TestLimitedInstanceServer(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		wait func(op incus.Operation) error
+	}{
+		{
+			name: "create error",
+			err:  errors.New("failed"),
+		},
+		{
+			name: "wait",
+			wait: func(op incus.Operation) error { return op.Wait() },
+		},
+		{
+			name: "wait context",
+			wait: func(op incus.Operation) error { return op.WaitContext(context.Background()) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := newOperationLimiter(1, 0)
+			server := &limitedInstanceServer{
+				InstanceServer: &fakeInstanceServer{op: &fakeOperation{}, err: tt.err},
+				limiter:        limiter,
+			}
+
+			op, err := server.CreateInstance(api.InstancesPost{})
+			if tt.err != nil {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				if len(limiter.operations) != 1 {
+					t.Fatal("Expected the slot to be held until the operation completes")
+				}
+
+				err = tt.wait(op)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			if len(limiter.operations) != 0 {
+				t.Fatal("Expected the slot to be released")
+			}
+		})
+	}
+}
+
+func TestLimitedInstanceServer_acquireTimeout(t *testing.T) {
+	limiter := newOperationLimiter(1, 0)
+	limiter.acquireTimeout = 10 * time.Millisecond
+
+	server := &limitedInstanceServer{
+		InstanceServer: &fakeInstanceServer{op: &fakeOperation{}},
+		limiter:        limiter,
+	}
+
+	_, err := server.CreateInstance(api.InstancesPost{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The slot is not released, so the next operation times out.
+	_, err = server.CreateInstance(api.InstancesPost{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
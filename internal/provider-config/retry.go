@@ -0,0 +1,208 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	incus "github.com/lxc/incus/v7/client"
+)
+
+// RetryPolicy defines how requests to an Incus remote are retried when they
+// fail with a transient error.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts for a single request.
+	// A value lower or equal to 1 disables retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. The delay doubles
+	// with each subsequent retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the upper bound for the delay between retries.
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes lists the HTTP status codes that are retried.
+	RetryableStatusCodes []int
+}
+
+// retryableErrorMessages lists error messages returned by a busy Incus server
+// for requests that can be retried as is.
+var retryableErrorMessages = []string{
+	"already in progress",
+}
+
+// NewRetryTransport returns an http.RoundTripper which retries requests sent
+// through the base transport according to the given policy.
+//
+// Requests that are not idempotent, such as the POST requests used to create
+// entities, are only retried if the server rejected them or if they never
+// reached the server. A request that may have been processed is not retried,
+// as this could create the entity twice.
+//
+// The returned transport implements incus.HTTPTransporter, as the Incus
+// client requires it for websocket connections (events, exec and file
+// transfers).
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		base:   base,
+		policy: policy,
+	}
+}
+
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests with a body that can not be replayed are sent only once.
+	if t.policy.MaxAttempts <= 1 || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxAttempts || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(t.backoff(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Transport implements incus.HTTPTransporter, returning the underlying
+// *http.Transport of the base transport.
+func (t *retryTransport) Transport() *http.Transport {
+	switch base := t.base.(type) {
+	case incus.HTTPTransporter:
+		return base.Transport()
+	case *http.Transport:
+		return base
+	default:
+		return nil
+	}
+}
+
+// shouldRetry determines whether the request should be sent again based on
+// the outcome of the last attempt.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+
+		// A request that failed to connect never reached the server.
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+
+		return isIdempotent(req.Method) && isTransientNetworkError(err)
+	}
+
+	// Requests that are not idempotent are only retried if the server
+	// explicitly rejected them, as they may have been processed otherwise.
+	if slices.Contains(t.policy.RetryableStatusCodes, resp.StatusCode) {
+		if isIdempotent(req.Method) || resp.StatusCode == http.StatusServiceUnavailable {
+			return true
+		}
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+
+	// Inspect the error message, restoring the body for the caller.
+	body, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return false
+	}
+
+	message := strings.ToLower(string(body))
+	for _, retryable := range retryableErrorMessages {
+		if strings.Contains(message, retryable) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the delay before the given retry attempt.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.policy.MinBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if t.policy.MaxBackoff > 0 && delay >= t.policy.MaxBackoff {
+			return t.policy.MaxBackoff
+		}
+	}
+
+	if t.policy.MaxBackoff > 0 && delay > t.policy.MaxBackoff {
+		return t.policy.MaxBackoff
+	}
+
+	return delay
+}
+
+// isIdempotent returns true if sending a request with the given method more
+// than once has the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransientNetworkError returns true if the error is caused by a dropped or
+// timed out connection.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
@@ -0,0 +1,248 @@
+package config_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	incus "github.com/lxc/incus/v7/client"
+
+	config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// fakeTransport returns the given responses in order and records the bodies
+// of the requests it receives.
+type fakeTransport struct {
+	responses []fakeResponse
+	bodies    []string
+}
+
+type fakeResponse struct {
+	status int
+	body   string
+	err    error
+}
+
+func (t *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := ""
+	if req.Body != nil {
+		data, _ := io.ReadAll(req.Body)
+		body = string(data)
+	}
+
+	t.bodies = append(t.bodies, body)
+
+	resp := t.responses[0]
+	if len(t.responses) > 1 {
+		t.responses = t.responses[1:]
+	}
+
+	if resp.err != nil {
+		return nil, resp.err
+	}
+
+	return &http.Response{
+		StatusCode: resp.status,
+		Body:       io.NopCloser(strings.NewReader(resp.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestRetryTransport(t *testing.T) {
+	policy := config.RetryPolicy{
+		MaxAttempts:          3,
+		RetryableStatusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	resetErr := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+
+	tests := []struct {
+		name         string
+		method       string
+		responses    []fakeResponse
+		wantStatus   int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{
+			name:         "success is not retried",
+			method:       http.MethodGet,
+			responses:    []fakeResponse{{status: http.StatusOK}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		{
+			name:         "retryable status code is retried",
+			method:       http.MethodGet,
+			responses:    []fakeResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "attempts are limited",
+			method:       http.MethodGet,
+			responses:    []fakeResponse{{status: http.StatusServiceUnavailable}},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+		},
+		{
+			name:         "other status code is not retried",
+			method:       http.MethodGet,
+			responses:    []fakeResponse{{status: http.StatusNotFound, body: `{"error": "Not found"}`}},
+			wantStatus:   http.StatusNotFound,
+			wantAttempts: 1,
+		},
+		{
+			name:         "operation in progress is retried",
+			method:       http.MethodPost,
+			responses:    []fakeResponse{{status: http.StatusBadRequest, body: `{"error": "Operation already in progress"}`}, {status: http.StatusOK}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "create rejected by the server is retried",
+			method:       http.MethodPost,
+			responses:    []fakeResponse{{status: http.StatusServiceUnavailable}, {status: http.StatusCreated}},
+			wantStatus:   http.StatusCreated,
+			wantAttempts: 2,
+		},
+		{
+			name:         "create with bad gateway is not retried",
+			method:       http.MethodPost,
+			responses:    []fakeResponse{{status: http.StatusBadGateway}, {status: http.StatusCreated}},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		{
+			name:         "create with gateway timeout is not retried",
+			method:       http.MethodPost,
+			responses:    []fakeResponse{{status: http.StatusGatewayTimeout}, {status: http.StatusCreated}},
+			wantStatus:   http.StatusGatewayTimeout,
+			wantAttempts: 1,
+		},
+		{
+			name:         "update with bad gateway is retried",
+			method:       http.MethodPut,
+			responses:    []fakeResponse{{status: http.StatusBadGateway}, {status: http.StatusOK}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "create that failed to connect is retried",
+			method:       http.MethodPost,
+			responses:    []fakeResponse{{err: dialErr}, {status: http.StatusCreated}},
+			wantStatus:   http.StatusCreated,
+			wantAttempts: 2,
+		},
+		{
+			name:         "create with dropped connection is not retried",
+			method:       http.MethodPost,
+			responses:    []fakeResponse{{err: resetErr}, {status: http.StatusCreated}},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "update with dropped connection is retried",
+			method:       http.MethodPut,
+			responses:    []fakeResponse{{err: resetErr}, {status: http.StatusOK}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := &fakeTransport{responses: tt.responses}
+			client := &http.Client{Transport: config.NewRetryTransport(base, policy)}
+
+			req, err := http.NewRequest(tt.method, "https://incus.example.com/1.0/instances", strings.NewReader(`{"name": "c1"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := client.Do(req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected an error, got status %d", resp.StatusCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				_ = resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Fatalf("Status = %d, want %d", resp.StatusCode, tt.wantStatus)
+				}
+			}
+
+			if len(base.bodies) != tt.wantAttempts {
+				t.Fatalf("Attempts = %d, want %d", len(base.bodies), tt.wantAttempts)
+			}
+
+			for _, body := range base.bodies {
+				if body != `{"name": "c1"}` {
+					t.Fatalf("Request body = %q, want it to be replayed", body)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransport_HTTPTransporter(t *testing.T) {
+	policy := config.RetryPolicy{MaxAttempts: 3}
+	base := &http.Transport{}
+
+	tests := []struct {
+		name      string
+		base      http.RoundTripper
+		transport *http.Transport
+	}{
+		{
+			name:      "http transport",
+			base:      base,
+			transport: base,
+		},
+		{
+			name:      "http transporter",
+			base:      &wrappedTransport{transport: base},
+			transport: base,
+		},
+		{
+			name: "other round tripper",
+			base: &fakeTransport{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The Incus client requires an HTTPTransporter to set up
+			// websocket connections.
+			transporter, ok := config.NewRetryTransport(tt.base, policy).(incus.HTTPTransporter)
+			if !ok {
+				t.Fatal("Retry transport does not implement incus.HTTPTransporter")
+			}
+
+			if transporter.Transport() != tt.transport {
+				t.Fatalf("Transport = %p, want %p", transporter.Transport(), tt.transport)
+			}
+		})
+	}
+}
+
+// wrappedTransport is an incus.HTTPTransporter wrapping an http.Transport.
+type wrappedTransport struct {
+	transport *http.Transport
+}
+
+func (t *wrappedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req)
+}
+
+func (t *wrappedTransport) Transport() *http.Transport {
+	return t.transport
+}
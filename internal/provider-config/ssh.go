@@ -0,0 +1,545 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	incus "github.com/lxc/incus/v7/client"
+	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
+	incus_shared "github.com/lxc/incus/v7/shared/util"
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// defaultRemoteUnixSocket is the path of the Incus unix socket on hosts
+// reached over SSH, when the remote address does not specify one.
+const defaultRemoteUnixSocket = "/var/lib/incus/unix.socket"
+
+// IncusProviderSSHConfig represents the SSH transport of an Incus remote.
+type IncusProviderSSHConfig struct {
+	// Host is the SSH server in the form of "host[:port]".
+	Host string
+
+	// User is the SSH user, also used for jump hosts without a user.
+	User string
+
+	// PrivateKey is the PEM-encoded private key. If empty, the keys of the
+	// SSH agent are used.
+	PrivateKey string
+
+	// KnownHosts is the content of a known_hosts file. If empty, the user's
+	// "~/.ssh/known_hosts" file is used.
+	KnownHosts string
+
+	// JumpHosts lists the hosts, in the form of "[user@]host[:port]", the
+	// connection is tunneled through before reaching Host.
+	JumpHosts []string
+}
+
+// key uniquely identifies the SSH connection, so that remotes reached
+// through the same host share a single connection.
+func (c IncusProviderSSHConfig) key() string {
+	hops := append(append([]string{}, c.JumpHosts...), c.User+"@"+c.Host)
+	return strings.Join(hops, ",")
+}
+
+// sshTunnel is a connection to an SSH server, which is re-established when
+// it is lost.
+type sshTunnel struct {
+	config IncusProviderSSHConfig
+
+	mu     sync.Mutex
+	client *sshClient
+}
+
+// sshClient is a client connected to an SSH server, which may be reached
+// through jump hosts. Closing it closes the connections to all hosts and to
+// the SSH agent.
+type sshClient struct {
+	*ssh.Client
+
+	// Connections to close, in the order they were opened.
+	closers []io.Closer
+}
+
+// Close closes all connections in reverse order.
+func (c *sshClient) Close() error {
+	var errs []error
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		errs = append(errs, c.closers[i].Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// Dial opens a connection to the given address through the SSH server.
+// The network can be "tcp" or "unix".
+func (t *sshTunnel) Dial(network string, address string) (net.Conn, error) {
+	client, err := t.connect(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.Dial(network, address)
+	if err == nil {
+		return conn, nil
+	}
+
+	// The connection may have been lost, so reconnect once.
+	client, err = t.connect(client)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Dial(network, address)
+}
+
+// connect returns the SSH client, connecting to the SSH server if there is
+// no client yet or if the current client is the given broken one.
+func (t *sshTunnel) connect(broken *sshClient) (*sshClient, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil && t.client != broken {
+		return t.client, nil
+	}
+
+	if t.client != nil {
+		_ = t.client.Close()
+		t.client = nil
+	}
+
+	client, err := dialSSH(t.config)
+	if err != nil {
+		return nil, err
+	}
+
+	t.client = client
+	return client, nil
+}
+
+// close closes the connection to the SSH server, if any.
+func (t *sshTunnel) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil {
+		_ = t.client.Close()
+		t.client = nil
+	}
+}
+
+// sshTunnel returns the SSH tunnel for the given configuration, reusing an
+// existing one if possible.
+func (p *IncusProviderConfig) sshTunnel(config IncusProviderSSHConfig) *sshTunnel {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	key := config.key()
+	tunnel, ok := p.sshTunnels[key]
+	if !ok {
+		tunnel = &sshTunnel{config: config}
+		p.sshTunnels[key] = tunnel
+	}
+
+	return tunnel
+}
+
+// createSSHInstanceServer creates an InstanceServer for a remote which is
+// reached through an SSH tunnel. Both HTTPS and unix socket addresses are
+// supported, unix socket addresses being resolved on the SSH host.
+func (p *IncusProviderConfig) createSSHInstanceServer(remote IncusProviderRemoteConfig) (incus.InstanceServer, error) {
+	tunnel := p.sshTunnel(*remote.SSH)
+
+	addresses := cleanRemoteAddresses(remote.Addresses)
+	if len(addresses) == 0 {
+		addresses = []string{"unix://"}
+	}
+
+	var connectErrs []error
+	for _, address := range addresses {
+		var server incus.InstanceServer
+		var err error
+
+		if strings.HasPrefix(address, "unix:") {
+			server, err = p.connectSSHUnixSocket(tunnel, remote, address)
+		} else {
+			server, err = p.connectSSHHTTPS(tunnel, remote, address)
+		}
+
+		if err != nil {
+			connectErrs = append(connectErrs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+
+		return server, nil
+	}
+
+	return nil, errors.Join(connectErrs...)
+}
+
+// connectSSHUnixSocket connects to the Incus unix socket of the SSH host by
+// forwarding a local unix socket to it.
+func (p *IncusProviderConfig) connectSSHUnixSocket(tunnel *sshTunnel, remote IncusProviderRemoteConfig, address string) (incus.InstanceServer, error) {
+	socketPath := strings.TrimPrefix(strings.TrimPrefix(address, "unix:"), "//")
+	if socketPath == "" {
+		socketPath = defaultRemoteUnixSocket
+	}
+
+	localPath, closeSocket, err := listenSSHUnixSocket(func() (net.Conn, error) {
+		return tunnel.Dial("unix", socketPath)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.mux.RLock()
+	userAgent := p.incusConfig.UserAgent
+	p.mux.RUnlock()
+
+	server, err := incus.ConnectIncusUnix(localPath, &incus.ConnectionArgs{UserAgent: userAgent})
+	if err != nil {
+		closeSocket()
+		return nil, err
+	}
+
+	p.mux.Lock()
+	p.cleanups = append(p.cleanups, closeSocket)
+	p.mux.Unlock()
+
+	return server, nil
+}
+
+// listenSSHUnixSocket listens on a unix socket in a new temporary directory
+// and forwards its connections to the connections returned by dial. The
+// returned function stops the forwarding and removes the directory.
+func listenSSHUnixSocket(dial func() (net.Conn, error)) (string, func(), error) {
+	localDir, err := os.MkdirTemp("", "terraform-provider-incus-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	localPath := filepath.Join(localDir, "unix.socket")
+	listener, err := net.Listen("unix", localPath)
+	if err != nil {
+		_ = os.RemoveAll(localDir)
+		return "", nil, err
+	}
+
+	go forwardSSHConnections(listener, dial)
+
+	var once sync.Once
+	closeSocket := func() {
+		once.Do(func() {
+			_ = listener.Close()
+			_ = os.RemoveAll(localDir)
+		})
+	}
+
+	return localPath, closeSocket, nil
+}
+
+// connectSSHHTTPS connects to the HTTPS endpoint of the remote, as reachable
+// from the SSH host. The server certificate is fetched through the tunnel if
+// it is not known yet, and the client is authenticated using the remote's
+// token if it is not trusted yet.
+func (p *IncusProviderConfig) connectSSHHTTPS(tunnel *sshTunnel, remote IncusProviderRemoteConfig, address string) (incus.InstanceServer, error) {
+	addressURL, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPort := addressURL.Host
+	if addressURL.Port() == "" {
+		hostPort = net.JoinHostPort(addressURL.Hostname(), "8443")
+	}
+
+	dial := func(_ context.Context, _ string, _ string) (net.Conn, error) {
+		return tunnel.Dial("tcp", hostPort)
+	}
+
+	p.mux.RLock()
+	certPath := p.incusConfig.ServerCertPath(remote.Name)
+	p.mux.RUnlock()
+
+	if remote.requiresServerCertificate() && !incus_shared.PathExists(certPath) {
+		if !p.acceptServerCertificate {
+			return nil, fmt.Errorf("Unable to communicate with remote server. Either set " +
+				"accept_remote_certificate to true or add the remote out of band " +
+				"of Terraform and try again.")
+		}
+
+		err := p.fetchSSHServerCertificate(dial, certPath, addressURL.Hostname())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get remote server certificate: %v", err)
+		}
+	}
+
+	p.mux.RLock()
+	args, err := p.connectionArgs(remote, incus_config.Remote{AuthType: remote.AuthenticationType})
+	p.mux.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	args.TransportWrapper = func(t *http.Transport) incus.HTTPTransporter {
+		t.Proxy = nil
+		t.DialContext = dial
+		return &sshTransport{transport: t}
+	}
+
+	server, err := incus.ConnectIncus(address, args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = authenticateToIncusServer(server, remote.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return server, nil
+}
+
+// fetchSSHServerCertificate retrieves the certificate of the remote through
+// the SSH tunnel and saves it to the given path.
+func (p *IncusProviderConfig) fetchSSHServerCertificate(dial func(context.Context, string, string) (net.Conn, error), certPath string, serverName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	conn, err := dial(ctx, "tcp", "")
+	if err != nil {
+		return err
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: serverName,
+
+		// The certificate is accepted on first use, as with
+		// accept_remote_certificate on directly reachable remotes.
+		InsecureSkipVerify: true, //nolint:gosec
+	})
+	defer func() { _ = tlsConn.Close() }()
+
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	certificates := tlsConn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return fmt.Errorf("Remote server did not present a certificate")
+	}
+
+	err = os.MkdirAll(filepath.Dir(certPath), 0o750)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificates[0].Raw}), 0o644)
+}
+
+// sshTransport is the HTTP transport of remotes reached through SSH.
+type sshTransport struct {
+	transport *http.Transport
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *sshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req)
+}
+
+// Transport implements incus.HTTPTransporter.
+func (t *sshTransport) Transport() *http.Transport {
+	return t.transport
+}
+
+// forwardSSHConnections forwards the connections accepted by the listener to
+// the connections returned by dial, until the listener is closed.
+func forwardSSHConnections(listener net.Listener, dial func() (net.Conn, error)) {
+	for {
+		local, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer func() { _ = local.Close() }()
+
+			remote, err := dial()
+			if err != nil {
+				return
+			}
+
+			defer func() { _ = remote.Close() }()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				_, _ = io.Copy(remote, local)
+				done <- struct{}{}
+			}()
+
+			go func() {
+				_, _ = io.Copy(local, remote)
+				done <- struct{}{}
+			}()
+
+			<-done
+		}()
+	}
+}
+
+// dialSSH connects to the SSH host of the given configuration, going through
+// its jump hosts in order.
+func dialSSH(config IncusProviderSSHConfig) (*sshClient, error) {
+	auth, agentConn, err := sshAuthMethod(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &sshClient{}
+	if agentConn != nil {
+		client.closers = append(client.closers, agentConn)
+	}
+
+	hostKeyCallback, err := sshHostKeyCallback(config.KnownHosts)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	hops := append(append([]string{}, config.JumpHosts...), config.User+"@"+config.Host)
+
+	for _, hop := range hops {
+		user, address := parseSSHHost(hop, config.User)
+		clientConfig := &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{auth},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         30 * time.Second,
+		}
+
+		if client.Client == nil {
+			hopClient, err := ssh.Dial("tcp", address, clientConfig)
+			if err != nil {
+				_ = client.Close()
+				return nil, fmt.Errorf("Failed to connect to SSH host %q: %w", address, err)
+			}
+
+			client.Client = hopClient
+			client.closers = append(client.closers, hopClient)
+			continue
+		}
+
+		conn, err := client.Dial("tcp", address)
+		if err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("Failed to connect to SSH host %q: %w", address, err)
+		}
+
+		clientConn, chans, reqs, err := ssh.NewClientConn(conn, address, clientConfig)
+		if err != nil {
+			_ = conn.Close()
+			_ = client.Close()
+			return nil, fmt.Errorf("Failed to connect to SSH host %q: %w", address, err)
+		}
+
+		// Keep the clients of the jump hosts, which carry the connection.
+		hopClient := ssh.NewClient(clientConn, chans, reqs)
+		client.Client = hopClient
+		client.closers = append(client.closers, hopClient)
+	}
+
+	return client, nil
+}
+
+// sshAuthMethod returns the authentication method for the given private key,
+// or the keys of the SSH agent if no private key is provided. In the latter
+// case, the connection to the SSH agent is returned as well, which must be
+// closed once the authentication method is no longer used.
+func sshAuthMethod(privateKey string) (ssh.AuthMethod, io.Closer, error) {
+	if privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to parse SSH private key: %w", err)
+		}
+
+		return ssh.PublicKeys(signer), nil, nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, nil, fmt.Errorf("No SSH private key provided and SSH_AUTH_SOCK is not set")
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to connect to SSH agent: %w", err)
+	}
+
+	return ssh.PublicKeysCallback(agent.NewClient(conn).Signers), conn, nil
+}
+
+// sshHostKeyCallback returns a callback verifying host keys against the
+// given known_hosts content, or the user's known_hosts file if empty.
+func sshHostKeyCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	if knownHosts == "" {
+		path, err := homedir.Expand("~/.ssh/known_hosts")
+		if err != nil {
+			return nil, err
+		}
+
+		return knownhosts.New(path)
+	}
+
+	// The knownhosts package only reads files.
+	file, err := os.CreateTemp("", "terraform-provider-incus-known-hosts-")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, err = file.WriteString(knownHosts)
+	if closeErr := file.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return knownhosts.New(file.Name())
+}
+
+// parseSSHHost splits a host in the form of "[user@]host[:port]" into
+// the user and the address, using the default user and port 22 if missing.
+func parseSSHHost(host string, defaultUser string) (string, string) {
+	user := defaultUser
+	before, after, found := strings.Cut(host, "@")
+	if found {
+		user = before
+		host = after
+	}
+
+	_, _, err := net.SplitHostPort(host)
+	if err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "22")
+	}
+
+	return user, host
+}
@@ -0,0 +1,327 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestParseSSHHost(t *testing.T) {
+	tests := []struct {
+		host        string
+		wantUser    string
+		wantAddress string
+	}{
+		{host: "host.example.com", wantUser: "default", wantAddress: "host.example.com:22"},
+		{host: "host.example.com:2222", wantUser: "default", wantAddress: "host.example.com:2222"},
+		{host: "admin@host.example.com", wantUser: "admin", wantAddress: "host.example.com:22"},
+		{host: "admin@host.example.com:2222", wantUser: "admin", wantAddress: "host.example.com:2222"},
+		{host: "192.0.2.1", wantUser: "default", wantAddress: "192.0.2.1:22"},
+		{host: "2001:db8::1", wantUser: "default", wantAddress: "[2001:db8::1]:22"},
+		{host: "[2001:db8::1]", wantUser: "default", wantAddress: "[2001:db8::1]:22"},
+		{host: "admin@[2001:db8::1]:2222", wantUser: "admin", wantAddress: "[2001:db8::1]:2222"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			user, address := parseSSHHost(tt.host, "default")
+			if user != tt.wantUser {
+				t.Fatalf("User = %q, want %q", user, tt.wantUser)
+			}
+
+			if address != tt.wantAddress {
+				t.Fatalf("Address = %q, want %q", address, tt.wantAddress)
+			}
+		})
+	}
+}
+
+func TestSSHHostKeyCallback(t *testing.T) {
+	hostKey := testSSHPublicKey(t)
+	otherKey := testSSHPublicKey(t)
+	knownHosts := knownhosts.Line([]string{"host.example.com:2222"}, hostKey) + "\n"
+
+	// The user's known_hosts file is used when no content is provided.
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	homedir.DisableCache = true
+	t.Cleanup(func() { homedir.DisableCache = false })
+
+	err := os.MkdirAll(filepath.Join(home, ".ssh"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(home, ".ssh", "known_hosts"), []byte(knownHosts), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		knownHosts string
+		host       string
+		key        ssh.PublicKey
+		wantErr    bool
+	}{
+		{
+			name:       "content known key",
+			knownHosts: knownHosts,
+			host:       "host.example.com:2222",
+			key:        hostKey,
+		},
+		{
+			name:       "content changed key",
+			knownHosts: knownHosts,
+			host:       "host.example.com:2222",
+			key:        otherKey,
+			wantErr:    true,
+		},
+		{
+			name:       "content unknown host",
+			knownHosts: knownHosts,
+			host:       "other.example.com:22",
+			key:        hostKey,
+			wantErr:    true,
+		},
+		{
+			name: "file known key",
+			host: "host.example.com:2222",
+			key:  hostKey,
+		},
+		{
+			name:    "file changed key",
+			host:    "host.example.com:2222",
+			key:     otherKey,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callback, err := sshHostKeyCallback(tt.knownHosts)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 2222}
+			err = callback(tt.host, remote, tt.key)
+			if tt.wantErr && err == nil {
+				t.Fatal("Expected an error")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	// A missing known_hosts file is an error.
+	err = os.Remove(filepath.Join(home, ".ssh", "known_hosts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sshHostKeyCallback("")
+	if err == nil {
+		t.Fatal("Expected an error for a missing known_hosts file")
+	}
+}
+
+func TestSSHAuthMethod(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The agent is only connected to, no keys are requested.
+	agentSock := filepath.Join(t.TempDir(), "agent.socket")
+	listener, err := net.Listen("unix", agentSock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = listener.Close() }()
+
+	tests := []struct {
+		name       string
+		privateKey string
+		authSock   string
+		wantErr    bool
+		wantAgent  bool
+	}{
+		{
+			name:       "private key",
+			privateKey: string(pem.EncodeToMemory(block)),
+		},
+		{
+			name:       "invalid private key",
+			privateKey: "invalid",
+			wantErr:    true,
+		},
+		{
+			name:    "no private key and no agent",
+			wantErr: true,
+		},
+		{
+			name:      "agent",
+			authSock:  agentSock,
+			wantAgent: true,
+		},
+		{
+			name:     "unreachable agent",
+			authSock: filepath.Join(t.TempDir(), "agent.socket"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SSH_AUTH_SOCK", tt.authSock)
+
+			auth, agentConn, err := sshAuthMethod(tt.privateKey)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if auth == nil {
+				t.Fatal("Expected an authentication method")
+			}
+
+			if (agentConn != nil) != tt.wantAgent {
+				t.Fatalf("Agent connection = %v, want agent connection: %t", agentConn, tt.wantAgent)
+			}
+
+			if agentConn != nil {
+				_ = agentConn.Close()
+			}
+		})
+	}
+}
+
+// testCloser records the order in which it is closed.
+type testCloser struct {
+	name   string
+	closed *[]string
+}
+
+func (c testCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return nil
+}
+
+func TestSSHClientClose(t *testing.T) {
+	var closed []string
+	client := &sshClient{
+		closers: []io.Closer{
+			testCloser{name: "agent", closed: &closed},
+			testCloser{name: "jump", closed: &closed},
+			testCloser{name: "host", closed: &closed},
+		},
+	}
+
+	err := client.Close()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := []string{"host", "jump", "agent"}
+	if !slices.Equal(closed, want) {
+		t.Fatalf("Closed = %v, want %v", closed, want)
+	}
+}
+
+func TestListenSSHUnixSocket(t *testing.T) {
+	// The forwarded connections are served by an echo server.
+	dial := func() (net.Conn, error) {
+		local, remote := net.Pipe()
+		go func() {
+			defer func() { _ = remote.Close() }()
+			_, _ = io.Copy(remote, remote)
+		}()
+
+		return local, nil
+	}
+
+	localPath, closeSocket, err := listenSSHUnixSocket(dial)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	conn, err := net.Dial("unix", localPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = conn.Write([]byte("ping"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reply := make([]byte, 4)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(reply) != "ping" {
+		t.Fatalf("Reply = %q, want %q", reply, "ping")
+	}
+
+	_ = conn.Close()
+
+	// Closing stops the forwarding and removes the temporary directory.
+	closeSocket()
+	closeSocket()
+
+	_, err = os.Stat(filepath.Dir(localPath))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected the socket directory to be removed, got: %v", err)
+	}
+
+	_, err = net.Dial("unix", localPath)
+	if err == nil {
+		t.Fatal("Expected the socket to be closed")
+	}
+}
+
+func testSSHPublicKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
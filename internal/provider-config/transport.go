@@ -0,0 +1,170 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	incus "github.com/lxc/incus/v7/client"
+	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
+	incus_shared "github.com/lxc/incus/v7/shared/util"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// hasTransportSettings returns true if the remote overrides the default
+// transport settings, which are otherwise taken from the environment.
+func (r IncusProviderRemoteConfig) hasTransportSettings() bool {
+	return r.ProxyURL != "" || r.NoProxy != "" || r.CACertificate != "" || r.InsecureSkipVerify
+}
+
+// proxyFunc returns the function selecting the proxy for requests sent to
+// the remote. The proxy and its exclusions default to the ones of the
+// environment (HTTPS_PROXY, HTTP_PROXY and NO_PROXY). Nil is returned if the
+// remote does not override them.
+func (r IncusProviderRemoteConfig) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if r.ProxyURL == "" && r.NoProxy == "" {
+		return nil
+	}
+
+	proxyConfig := httpproxy.FromEnvironment()
+	if r.ProxyURL != "" {
+		proxyConfig.HTTPProxy = r.ProxyURL
+		proxyConfig.HTTPSProxy = r.ProxyURL
+	}
+
+	if r.NoProxy != "" {
+		proxyConfig.NoProxy = r.NoProxy
+	}
+
+	proxy := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxy(req.URL)
+	}
+}
+
+// applyTransportSettings sets the proxy and TLS verification settings of
+// the remote on the given connection arguments.
+func (r IncusProviderRemoteConfig) applyTransportSettings(args *incus.ConnectionArgs) {
+	proxy := r.proxyFunc()
+	if proxy != nil {
+		args.Proxy = proxy
+	}
+
+	if r.CACertificate != "" {
+		args.TLSCA = r.CACertificate
+	}
+
+	args.InsecureSkipVerify = r.InsecureSkipVerify
+}
+
+// requiresServerCertificate returns true if the remote's certificate must be
+// known in advance, that is when it can neither be verified against a CA nor
+// skipped.
+func (r IncusProviderRemoteConfig) requiresServerCertificate() bool {
+	return r.ServerCertificate == "" && r.CACertificate == "" && !r.InsecureSkipVerify
+}
+
+// connectImageServer connects to the given image server remote using the
+// remote's transport settings. The caller must hold the mutex.
+func (p *IncusProviderConfig) connectImageServer(remote IncusProviderRemoteConfig, incusRemote incus_config.Remote) (incus.ImageServer, error) {
+	args, err := p.imageServerArgs(remote, incusRemote)
+	if err != nil {
+		return nil, err
+	}
+
+	var connectErrs []error
+	for _, address := range incusRemote.Addrs {
+		var server incus.ImageServer
+		var err error
+
+		switch {
+		case strings.HasPrefix(address, "unix:"):
+			return nil, fmt.Errorf("Remote %q: proxy and TLS settings are not supported for unix socket addresses", remote.Name)
+		case incusRemote.Protocol == "simplestreams":
+			server, err = incus.ConnectSimpleStreams(address, args)
+		case incusRemote.Protocol == "oci":
+			server, err = incus.ConnectOCI(address, args)
+		case incusRemote.Public:
+			server, err = incus.ConnectPublicIncus(address, args)
+		default:
+			server, err = incus.ConnectIncus(address, args)
+		}
+
+		if err != nil {
+			connectErrs = append(connectErrs, fmt.Errorf("%s: %w", address, err))
+			continue
+		}
+
+		return server, nil
+	}
+
+	return nil, errors.Join(connectErrs...)
+}
+
+// imageServerArgs returns the arguments to connect to the given image server
+// remote. The server certificate is read from the Incus config directory,
+// unless it is verified against a CA or not verified at all. The caller must
+// hold the mutex.
+func (p *IncusProviderConfig) imageServerArgs(remote IncusProviderRemoteConfig, incusRemote incus_config.Remote) (*incus.ConnectionArgs, error) {
+	args := &incus.ConnectionArgs{
+		UserAgent:  p.incusConfig.UserAgent,
+		CredHelper: incusRemote.CredHelper,
+	}
+
+	remote.applyTransportSettings(args)
+
+	if args.TLSCA == "" && !args.InsecureSkipVerify {
+		certPath := p.incusConfig.ServerCertPath(remote.Name)
+		if incus_shared.PathExists(certPath) {
+			cert, err := os.ReadFile(certPath)
+			if err != nil {
+				return nil, err
+			}
+
+			args.TLSServerCert = string(cert)
+		}
+	}
+
+	return args, nil
+}
+
+// getRemoteCertificate retrieves the certificate presented by the server at
+// the given address, going through the given proxy.
+func getRemoteCertificate(address string, userAgent string, proxy func(*http.Request) (*url.URL, error)) (*x509.Certificate, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy: proxy,
+
+			// The certificate is retrieved in order to be trusted on first
+			// use, so it can not be verified yet.
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("Unable to read remote TLS certificate")
+	}
+
+	return resp.TLS.PeerCertificates[0], nil
+}
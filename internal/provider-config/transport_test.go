@@ -0,0 +1,209 @@
+package config
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	incus "github.com/lxc/incus/v7/client"
+	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
+)
+
+// connectionArgsBuilders returns the functions building the connection
+// arguments of instance and image server remotes.
+func connectionArgsBuilders(p *IncusProviderConfig) map[string]func(IncusProviderRemoteConfig) (*incus.ConnectionArgs, error) {
+	return map[string]func(IncusProviderRemoteConfig) (*incus.ConnectionArgs, error){
+		"instance server": func(remote IncusProviderRemoteConfig) (*incus.ConnectionArgs, error) {
+			return p.connectionArgs(remote, incus_config.Remote{})
+		},
+		"image server": func(remote IncusProviderRemoteConfig) (*incus.ConnectionArgs, error) {
+			return p.imageServerArgs(remote, incus_config.Remote{Protocol: "simplestreams"})
+		},
+	}
+}
+
+func TestConnectionArgs_proxy(t *testing.T) {
+	tests := []struct {
+		name      string
+		remote    IncusProviderRemoteConfig
+		url       string
+		wantProxy string
+		wantNil   bool
+	}{
+		{
+			name:    "environment",
+			url:     "https://incus.example.com:8443",
+			wantNil: true,
+		},
+		{
+			name:      "proxy url",
+			remote:    IncusProviderRemoteConfig{ProxyURL: "http://proxy.example.com:3128"},
+			url:       "https://incus.example.com:8443",
+			wantProxy: "http://proxy.example.com:3128",
+		},
+		{
+			name:      "proxy url over http",
+			remote:    IncusProviderRemoteConfig{ProxyURL: "http://proxy.example.com:3128"},
+			url:       "http://images.example.com",
+			wantProxy: "http://proxy.example.com:3128",
+		},
+		{
+			name:   "proxy url excluded by no proxy",
+			remote: IncusProviderRemoteConfig{ProxyURL: "http://proxy.example.com:3128", NoProxy: "incus.example.com"},
+			url:    "https://incus.example.com:8443",
+		},
+		{
+			name:   "proxy url excluded by domain",
+			remote: IncusProviderRemoteConfig{ProxyURL: "http://proxy.example.com:3128", NoProxy: ".example.com"},
+			url:    "https://incus.example.com:8443",
+		},
+		{
+			name:   "proxy url excluded by network",
+			remote: IncusProviderRemoteConfig{ProxyURL: "http://proxy.example.com:3128", NoProxy: "10.0.0.0/8"},
+			url:    "https://10.1.2.3:8443",
+		},
+		{
+			name:      "proxy url not excluded",
+			remote:    IncusProviderRemoteConfig{ProxyURL: "http://proxy.example.com:3128", NoProxy: "other.example.com"},
+			url:       "https://incus.example.com:8443",
+			wantProxy: "http://proxy.example.com:3128",
+		},
+		{
+			name:   "environment proxy excluded by no proxy",
+			remote: IncusProviderRemoteConfig{NoProxy: "incus.example.com"},
+			url:    "https://incus.example.com:8443",
+		},
+		{
+			name:      "environment proxy not excluded",
+			remote:    IncusProviderRemoteConfig{NoProxy: "incus.example.com"},
+			url:       "https://other.example.com:8443",
+			wantProxy: "http://env-proxy.example.com:3128",
+		},
+	}
+
+	for _, name := range []string{"HTTP_PROXY", "http_proxy", "https_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(name, "")
+	}
+
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+
+	p := NewIncusProvider(&incus_config.Config{ConfigDir: t.TempDir()}, false)
+
+	for builderName, build := range connectionArgsBuilders(p) {
+		for _, tt := range tests {
+			t.Run(builderName+"/"+tt.name, func(t *testing.T) {
+				args, err := build(tt.remote)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				if tt.wantNil {
+					if args.Proxy != nil {
+						t.Fatal("Expected the proxy of the environment to be used")
+					}
+
+					return
+				}
+
+				if args.Proxy == nil {
+					t.Fatal("Expected a proxy function")
+				}
+
+				req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				proxyURL, err := args.Proxy(req)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				got := ""
+				if proxyURL != nil {
+					got = proxyURL.String()
+				}
+
+				if got != tt.wantProxy {
+					t.Fatalf("Proxy = %q, want %q", got, tt.wantProxy)
+				}
+			})
+		}
+	}
+}
+
+func TestConnectionArgs_tls(t *testing.T) {
+	const caCertificate = "-----BEGIN CERTIFICATE-----\nca\n-----END CERTIFICATE-----\n"
+	const serverCertificate = "-----BEGIN CERTIFICATE-----\nserver\n-----END CERTIFICATE-----\n"
+
+	tests := []struct {
+		name           string
+		remote         IncusProviderRemoteConfig
+		wantCA         string
+		wantServerCert string
+		wantInsecure   bool
+	}{
+		{
+			name:           "server certificate from disk",
+			remote:         IncusProviderRemoteConfig{Name: "remote"},
+			wantServerCert: serverCertificate,
+		},
+		{
+			name:   "ca certificate",
+			remote: IncusProviderRemoteConfig{Name: "remote", CACertificate: caCertificate},
+			wantCA: caCertificate,
+		},
+		{
+			name:         "insecure skip verify",
+			remote:       IncusProviderRemoteConfig{Name: "remote", InsecureSkipVerify: true},
+			wantInsecure: true,
+		},
+		{
+			name:         "ca certificate and insecure skip verify",
+			remote:       IncusProviderRemoteConfig{Name: "remote", CACertificate: caCertificate, InsecureSkipVerify: true},
+			wantCA:       caCertificate,
+			wantInsecure: true,
+		},
+		{
+			name:   "no server certificate on disk",
+			remote: IncusProviderRemoteConfig{Name: "other"},
+		},
+	}
+
+	configDir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(configDir, "servercerts"), 0o700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(configDir, "servercerts", "remote.crt"), []byte(serverCertificate), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewIncusProvider(&incus_config.Config{ConfigDir: configDir}, false)
+
+	for builderName, build := range connectionArgsBuilders(p) {
+		for _, tt := range tests {
+			t.Run(builderName+"/"+tt.name, func(t *testing.T) {
+				args, err := build(tt.remote)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				if args.TLSCA != tt.wantCA {
+					t.Fatalf("TLSCA = %q, want %q", args.TLSCA, tt.wantCA)
+				}
+
+				if args.TLSServerCert != tt.wantServerCert {
+					t.Fatalf("TLSServerCert = %q, want %q", args.TLSServerCert, tt.wantServerCert)
+				}
+
+				if args.InsecureSkipVerify != tt.wantInsecure {
+					t.Fatalf("InsecureSkipVerify = %t, want %t", args.InsecureSkipVerify, tt.wantInsecure)
+				}
+			})
+		}
+	}
+}
@@ -2,12 +2,22 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -17,8 +27,11 @@ import (
 	incus_config "github.com/lxc/incus/v7/shared/cliconfig"
 	incus_shared "github.com/lxc/incus/v7/shared/util"
 
+	"github.com/lxc/terraform-provider-incus/internal/auth"
 	"github.com/lxc/terraform-provider-incus/internal/certificate"
 	"github.com/lxc/terraform-provider-incus/internal/cluster"
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/functions"
 	"github.com/lxc/terraform-provider-incus/internal/image"
 	"github.com/lxc/terraform-provider-incus/internal/instance"
 	"github.com/lxc/terraform-provider-incus/internal/network"
@@ -38,29 +51,105 @@ type IncusProviderRemoteModel struct {
 	CredentialsHelper  types.String `tfsdk:"credentials_helper"`
 	AuthenticationType types.String `tfsdk:"authentication_type"`
 	Token              types.String `tfsdk:"token"`
+	ClientCertificate  types.String `tfsdk:"client_certificate"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ServerCertificate  types.String `tfsdk:"server_certificate"`
 	Public             types.Bool   `tfsdk:"public"`
+
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	NoProxy            types.String `tfsdk:"no_proxy"`
+	CACertificate      types.String `tfsdk:"ca_certificate"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	MaxConcurrentOperations     types.Int64 `tfsdk:"max_concurrent_operations"`
+	MaxConcurrentImageDownloads types.Int64 `tfsdk:"max_concurrent_image_downloads"`
+
+	SSH *IncusProviderSSHModel `tfsdk:"ssh"`
+}
+
+// IncusProviderSSHModel represents provider's schema remote SSH transport.
+type IncusProviderSSHModel struct {
+	Host       types.String `tfsdk:"host"`
+	User       types.String `tfsdk:"user"`
+	PrivateKey types.String `tfsdk:"private_key"`
+	KnownHosts types.String `tfsdk:"known_hosts"`
+	JumpHosts  types.List   `tfsdk:"jump_hosts"`
+}
+
+// IncusProviderRetryModel represents provider's schema retry.
+type IncusProviderRetryModel struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	MinBackoff           types.String `tfsdk:"min_backoff"`
+	MaxBackoff           types.String `tfsdk:"max_backoff"`
+	RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
 }
 
 // IncusProviderModel represents provider's schema.
 type IncusProviderModel struct {
 	Remotes                    []IncusProviderRemoteModel `tfsdk:"remote"`
+	Retry                      *IncusProviderRetryModel   `tfsdk:"retry"`
 	DefaultRemote              types.String               `tfsdk:"default_remote"`
 	ConfigDir                  types.String               `tfsdk:"config_dir"`
 	AcceptRemoteCertificate    types.Bool                 `tfsdk:"accept_remote_certificate"`
 	GenerateClientCertificates types.Bool                 `tfsdk:"generate_client_certificates"`
 }
 
+// providers lists the provider instances, so that the resources held by
+// their configuration can be released by Close.
+var (
+	providersMu sync.Mutex
+	providers   = map[*IncusProvider]struct{}{}
+)
+
+// Close releases the resources held by the configured providers, such as the
+// local unix sockets forwarded to remotes reached through SSH. It is called
+// once the provider server has stopped.
+func Close() {
+	providersMu.Lock()
+	instances := providers
+	providers = map[*IncusProvider]struct{}{}
+	providersMu.Unlock()
+
+	for p := range instances {
+		p.setConfig(nil)
+	}
+}
+
 // IncusProvider ...
 type IncusProvider struct {
 	version string
+
+	// config is the provider configuration created by Configure. It is
+	// replaced when the provider is configured again.
+	config *provider_config.IncusProviderConfig
+	mux    sync.Mutex
 }
 
 // New returns Incus provider with the given version set.
 func NewIncusProvider(version string) func() provider.Provider {
 	return func() provider.Provider {
-		return &IncusProvider{
+		p := &IncusProvider{
 			version: version,
 		}
+
+		providersMu.Lock()
+		providers[p] = struct{}{}
+		providersMu.Unlock()
+
+		return p
+	}
+}
+
+// setConfig replaces the provider configuration and releases the resources
+// held by the previous one.
+func (p *IncusProvider) setConfig(config *provider_config.IncusProviderConfig) {
+	p.mux.Lock()
+	previous := p.config
+	p.config = config
+	p.mux.Unlock()
+
+	if previous != nil && previous != config {
+		previous.Close()
 	}
 }
 
@@ -93,6 +182,44 @@ func (p *IncusProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 		},
 
 		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				Description: "Retry policy for transient Incus API failures",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Maximum number of attempts for a single request. (default = 3)",
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+
+					"min_backoff": schema.StringAttribute{
+						Optional:    true,
+						Description: "Delay before the first retry, doubled on each subsequent retry. (default = 1s)",
+						Validators: []validator.String{
+							common.DurationValidator{},
+						},
+					},
+
+					"max_backoff": schema.StringAttribute{
+						Optional:    true,
+						Description: "Maximum delay between retries. (default = 30s)",
+						Validators: []validator.String{
+							common.DurationValidator{},
+						},
+					},
+
+					"retryable_status_codes": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.Int64Type,
+						Description: "HTTP status codes which are retried. (default = [502, 503, 504])",
+						Validators: []validator.List{
+							listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+						},
+					},
+				},
+			},
+
 			"remote": schema.ListNestedBlock{
 				Description: "Incus Remote",
 				NestedObject: schema.NestedBlockObject{
@@ -143,6 +270,117 @@ func (p *IncusProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp
 							Sensitive:   true,
 							Description: "The trust token used for initial authentication with the Incus remote.",
 						},
+
+						"client_certificate": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "PEM-encoded client certificate used to authenticate with the Incus remote. ( Only for the `incus` protocol )",
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_key")),
+								provider_validators.CheckProtocol("incus"),
+							},
+						},
+
+						"client_key": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "PEM-encoded private key of the client certificate. ( Only for the `incus` protocol )",
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("client_certificate")),
+								provider_validators.CheckProtocol("incus"),
+							},
+						},
+
+						"server_certificate": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "PEM-encoded certificate of the Incus remote. ( Only for the `incus` protocol )",
+							Validators: []validator.String{
+								provider_validators.CheckProtocol("incus"),
+							},
+						},
+
+						"proxy_url": schema.StringAttribute{
+							Optional:    true,
+							Description: "URL of the HTTP proxy used to reach the remote. (default = $HTTPS_PROXY / $HTTP_PROXY)",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+
+						"no_proxy": schema.StringAttribute{
+							Optional:    true,
+							Description: "Comma-separated list of hosts, domains and networks not reached through the proxy. (default = $NO_PROXY)",
+						},
+
+						"ca_certificate": schema.StringAttribute{
+							Optional:    true,
+							Description: "PEM-encoded CA certificate used to verify the remote's certificate.",
+						},
+
+						"insecure_skip_verify": schema.BoolAttribute{
+							Optional:    true,
+							Description: "Skip the verification of the remote's certificate. This is insecure and should only be used for testing.",
+						},
+
+						"max_concurrent_operations": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Maximum number of operations (create, copy, migrate, exec) run concurrently on the Incus remote. (default = unlimited)",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+
+						"max_concurrent_image_downloads": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Maximum number of operations which may download an image run concurrently on the Incus remote. (default = unlimited)",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+
+					Blocks: map[string]schema.Block{
+						"ssh": schema.SingleNestedBlock{
+							Description: "SSH transport used to reach the Incus remote. ( Only for the `incus` protocol )",
+							Attributes: map[string]schema.Attribute{
+								"host": schema.StringAttribute{
+									Optional:    true,
+									Description: "SSH host in the form of host[:port].",
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+
+								"user": schema.StringAttribute{
+									Optional:    true,
+									Description: "SSH user.",
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+
+								"private_key": schema.StringAttribute{
+									Optional:    true,
+									Sensitive:   true,
+									Description: "PEM-encoded SSH private key. (default = keys of the SSH agent)",
+								},
+
+								"known_hosts": schema.StringAttribute{
+									Optional:    true,
+									Description: "Content of the known_hosts file used to verify host keys. (default = $HOME/.ssh/known_hosts)",
+								},
+
+								"jump_hosts": schema.ListAttribute{
+									Optional:    true,
+									ElementType: types.StringType,
+									Description: "SSH jump hosts, in the form of [user@]host[:port], to go through in order.",
+									Validators: []validator.List{
+										listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -215,6 +453,17 @@ func (p *IncusProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	// provider's configuration for reference throughout the lifecycle.
 	incusProvider := provider_config.NewIncusProvider(config, acceptServerCertificate)
 
+	// Retry transient failures only if explicitly requested.
+	if data.Retry != nil {
+		retryPolicy, diags := toRetryPolicy(ctx, *data.Retry)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		incusProvider.SetRetryPolicy(retryPolicy)
+	}
+
 	// Create Incus remote from environment variables (if defined).
 	// This emulates the Terraform provider "remote" config:
 	//
@@ -250,7 +499,7 @@ func (p *IncusProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	// This lazy loading allows this Incus provider to be used
 	// in Terraform configurations where the Incus remote might not
 	// exist yet.
-	for _, remote := range data.Remotes {
+	for i, remote := range data.Remotes {
 		isDefault := false
 
 		protocol := remote.Protocol.ValueString()
@@ -270,7 +519,43 @@ func (p *IncusProvider) Configure(ctx context.Context, req provider.ConfigureReq
 			CredentialsHelper:  remote.CredentialsHelper.ValueString(),
 			AuthenticationType: autheticationType,
 			Token:              remote.Token.ValueString(),
+			ClientCertificate:  remote.ClientCertificate.ValueString(),
+			ClientKey:          remote.ClientKey.ValueString(),
+			ServerCertificate:  remote.ServerCertificate.ValueString(),
 			Public:             remote.Public.ValueBool(),
+			ProxyURL:           remote.ProxyURL.ValueString(),
+			NoProxy:            remote.NoProxy.ValueString(),
+			CACertificate:      remote.CACertificate.ValueString(),
+			InsecureSkipVerify: remote.InsecureSkipVerify.ValueBool(),
+
+			MaxConcurrentOperations:     int(remote.MaxConcurrentOperations.ValueInt64()),
+			MaxConcurrentImageDownloads: int(remote.MaxConcurrentImageDownloads.ValueInt64()),
+		}
+
+		if incusProviderRemoteConfig.ProxyURL != "" {
+			proxyURL, err := url.Parse(incusProviderRemoteConfig.ProxyURL)
+			if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+				resp.Diagnostics.AddAttributeError(path.Root("remote").AtListIndex(i).AtName("proxy_url"), "Invalid proxy URL", fmt.Sprintf("Proxy URL %q of remote %q must be an absolute URL, such as \"http://proxy.example.com:3128\"", incusProviderRemoteConfig.ProxyURL, incusProviderRemoteConfig.Name))
+				return
+			}
+		}
+
+		if incusProviderRemoteConfig.InsecureSkipVerify {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("remote").AtListIndex(i).AtName("insecure_skip_verify"),
+				"Insecure remote",
+				fmt.Sprintf("The certificate of remote %q is not verified. The connection is vulnerable to man-in-the-middle attacks, and any credentials sent to the remote may be intercepted. Use \"ca_certificate\" or \"server_certificate\" instead.", incusProviderRemoteConfig.Name),
+			)
+		}
+
+		if remote.SSH != nil {
+			sshConfig, diags := toSSHConfig(ctx, path.Root("remote").AtListIndex(i).AtName("ssh"), protocol, *remote.SSH)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			incusProviderRemoteConfig.SSH = &sshConfig
 		}
 
 		if data.DefaultRemote.ValueString() == remote.Name.ValueString() {
@@ -281,15 +566,26 @@ func (p *IncusProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	log.Printf("[DEBUG] Incus Provider: %#v", &incusProvider)
 
+	p.setConfig(incusProvider)
+
 	resp.ResourceData = incusProvider
 	resp.DataSourceData = incusProvider
+	resp.EphemeralResourceData = incusProvider
+	resp.ListResourceData = incusProvider
 }
 
 func (p *IncusProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		auth.NewAuthGroupResource,
+		auth.NewIdentityProviderGroupResource,
+		auth.NewIdentityResource,
 		certificate.NewCertificateResource,
+		certificate.NewCertificateTokenResource,
 		cluster.NewClusterGroupResource,
+		cluster.NewClusterJoinTokenResource,
+		cluster.NewClusterMemberResource,
 		image.NewImageResource,
+		instance.NewInstanceBackupResource,
 		instance.NewInstanceResource,
 		instance.NewInstanceSnapshotResource,
 		network.NewNetworkACLResource,
@@ -307,7 +603,9 @@ func (p *IncusProvider) Resources(_ context.Context) []func() resource.Resource
 		storage.NewStorageBucketKeyResource,
 		storage.NewStorageBucketResource,
 		storage.NewStoragePoolResource,
+		storage.NewStorageVolumeBackupResource,
 		storage.NewStorageVolumeResource,
+		storage.NewStorageVolumeSnapshotResource,
 	}
 }
 
@@ -317,3 +615,117 @@ func (p *IncusProvider) DataSources(_ context.Context) []func() datasource.DataS
 		image.NewImageDataSource,
 	}, generatedDataSources()...)
 }
+
+func (p *IncusProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		certificate.NewCertificateTokenEphemeralResource,
+		storage.NewStorageBucketKeyEphemeralResource,
+	}
+}
+
+func (p *IncusProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		image.NewImageListResource,
+		instance.NewInstanceListResource,
+		network.NewNetworkListResource,
+		profile.NewProfileListResource,
+		storage.NewStorageVolumeListResource,
+	}
+}
+
+func (p *IncusProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewImportIDFunction,
+		functions.NewParseImageRefFunction,
+		functions.NewParseImportIDFunction,
+	}
+}
+
+// toRetryPolicy converts the provider's retry block into a retry policy,
+// applying the defaults for unset attributes.
+func toRetryPolicy(ctx context.Context, m IncusProviderRetryModel) (provider_config.RetryPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	policy := provider_config.RetryPolicy{
+		MaxAttempts:          3,
+		MinBackoff:           1 * time.Second,
+		MaxBackoff:           30 * time.Second,
+		RetryableStatusCodes: []int{502, 503, 504},
+	}
+
+	if !m.MaxAttempts.IsNull() && !m.MaxAttempts.IsUnknown() {
+		policy.MaxAttempts = int(m.MaxAttempts.ValueInt64())
+	}
+
+	if !m.MinBackoff.IsNull() && !m.MinBackoff.IsUnknown() {
+		duration, err := time.ParseDuration(m.MinBackoff.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("retry").AtName("min_backoff"), "Invalid duration", err.Error())
+			return policy, diags
+		}
+
+		policy.MinBackoff = duration
+	}
+
+	if !m.MaxBackoff.IsNull() && !m.MaxBackoff.IsUnknown() {
+		duration, err := time.ParseDuration(m.MaxBackoff.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("retry").AtName("max_backoff"), "Invalid duration", err.Error())
+			return policy, diags
+		}
+
+		policy.MaxBackoff = duration
+	}
+
+	if policy.MaxBackoff < policy.MinBackoff {
+		diags.AddAttributeError(path.Root("retry").AtName("max_backoff"), "Invalid retry policy", "max_backoff must not be lower than min_backoff")
+		return policy, diags
+	}
+
+	if !m.RetryableStatusCodes.IsNull() && !m.RetryableStatusCodes.IsUnknown() {
+		codes := make([]int64, 0, len(m.RetryableStatusCodes.Elements()))
+		diags.Append(m.RetryableStatusCodes.ElementsAs(ctx, &codes, false)...)
+		if diags.HasError() {
+			return policy, diags
+		}
+
+		policy.RetryableStatusCodes = make([]int, 0, len(codes))
+		for _, code := range codes {
+			policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, int(code))
+		}
+	}
+
+	return policy, diags
+}
+
+// toSSHConfig converts the SSH transport of the remote at the given path
+// into its provider configuration.
+func toSSHConfig(ctx context.Context, sshPath path.Path, protocol string, m IncusProviderSSHModel) (provider_config.IncusProviderSSHConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	config := provider_config.IncusProviderSSHConfig{
+		Host:       m.Host.ValueString(),
+		User:       m.User.ValueString(),
+		PrivateKey: m.PrivateKey.ValueString(),
+		KnownHosts: m.KnownHosts.ValueString(),
+	}
+
+	if protocol != "incus" {
+		diags.AddAttributeError(sshPath, "Invalid SSH transport", "The SSH transport is only supported for the \"incus\" protocol")
+		return config, diags
+	}
+
+	if config.Host == "" {
+		diags.AddAttributeError(sshPath.AtName("host"), "Missing SSH host", "The SSH transport requires a host")
+	}
+
+	if config.User == "" {
+		diags.AddAttributeError(sshPath.AtName("user"), "Missing SSH user", "The SSH transport requires a user")
+	}
+
+	if !m.JumpHosts.IsNull() && !m.JumpHosts.IsUnknown() {
+		diags.Append(m.JumpHosts.ElementsAs(ctx, &config.JumpHosts, false)...)
+	}
+
+	return config, diags
+}
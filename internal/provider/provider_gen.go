@@ -4,6 +4,7 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/lxc/terraform-provider-incus/internal/auth"
 	"github.com/lxc/terraform-provider-incus/internal/certificate"
 	"github.com/lxc/terraform-provider-incus/internal/instance"
 	"github.com/lxc/terraform-provider-incus/internal/network"
@@ -14,9 +15,14 @@ import (
 
 func generatedDataSources() []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		auth.NewAuthGroupDataSource,
 		certificate.NewCertificateDataSource,
+		auth.NewIdentityDataSource,
+		auth.NewIdentityProviderGroupDataSource,
 		instance.NewInstanceDataSource,
+		instance.NewInstancesDataSource,
 		network.NewNetworkDataSource,
+		network.NewNetworksDataSource,
 		network.NewNetworkACLDataSource,
 		network.NewNetworkAddressSetDataSource,
 		network.NewNetworkForwardDataSource,
@@ -29,5 +35,6 @@ func generatedDataSources() []func() datasource.DataSource {
 		storage.NewStorageBucketDataSource,
 		storage.NewStoragePoolDataSource,
 		storage.NewStorageVolumeDataSource,
+		storage.NewStorageVolumesDataSource,
 	}
 }
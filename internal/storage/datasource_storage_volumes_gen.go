@@ -0,0 +1,199 @@
+// Code generated by generate-datasources; DO NOT EDIT.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type StorageVolumesDataSourceModel struct {
+	StoragePool types.String `tfsdk:"storage_pool"`
+	Project     types.String `tfsdk:"project"`
+	Target      types.String `tfsdk:"target"`
+	Remote      types.String `tfsdk:"remote"`
+
+	// Filters.
+	Filters types.List `tfsdk:"filters"`
+	Config  types.Map  `tfsdk:"config"`
+
+	StorageVolumes types.Map `tfsdk:"storage_volumes"`
+}
+
+type StorageVolumesDataSource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+func NewStorageVolumesDataSource() datasource.DataSource {
+	return &StorageVolumesDataSource{}
+}
+
+func (d *StorageVolumesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_volumes", req.ProviderTypeName)
+}
+
+func (d *StorageVolumesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"storage_pool": schema.StringAttribute{
+				Required: true,
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"target": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"filters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"config": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"storage_volumes": schema.MapAttribute{
+				Computed:    true,
+				ElementType: getStorageVolumesElemType(),
+			},
+		},
+	}
+}
+
+func (d *StorageVolumesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	d.provider = provider
+}
+
+func (d *StorageVolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state StorageVolumesDataSourceModel
+
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerRemote := state.Remote.ValueString()
+	providerProjectName := state.Project.ValueString()
+	providerTarget := state.Target.ValueString()
+	server, err := d.provider.InstanceServer(providerRemote, providerProjectName, providerTarget)
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	var filters []string
+	diags = state.Filters.ElementsAs(ctx, &filters, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, state.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	storagePoolName := state.StoragePool.ValueString()
+
+	storageVolumes, err := server.GetStoragePoolVolumesWithFilter(storagePoolName, filters)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve storage volumes of storage pool %q", storagePoolName), err.Error())
+		return
+	}
+
+	elemType := getStorageVolumesElemType()
+	elems := make(map[string]attr.Value, len(storageVolumes))
+
+	for _, storageVolume := range storageVolumes {
+		if !common.MatchConfig(storageVolume.Config, configFilter) {
+			continue
+		}
+
+		attrs := map[string]attr.Value{
+			"name":         types.StringValue(storageVolume.Name),
+			"type":         types.StringValue(storageVolume.Type),
+			"description":  types.StringValue(storageVolume.Description),
+			"location":     types.StringValue(storageVolume.Location),
+			"content_type": types.StringValue(storageVolume.ContentType),
+		}
+
+		attrs["config"], diags = common.ToConfigMapType(ctx, common.ToNullableConfig(storageVolume.Config), types.MapNull(types.StringType))
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		// The storage volume name is only unique within its type
+		// and, on clustered local storage, its location.
+		key := fmt.Sprintf("%s/%s", storageVolume.Type, storageVolume.Name)
+		if storageVolume.Location != "" && storageVolume.Location != "none" {
+			key = fmt.Sprintf("%s@%s", key, storageVolume.Location)
+		}
+		elems[key], diags = types.ObjectValue(elemType.AttrTypes, attrs)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	state.StorageVolumes, diags = types.MapValue(elemType, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func getStorageVolumesElemType() types.ObjectType {
+	return types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"name":         types.StringType,
+			"type":         types.StringType,
+			"description":  types.StringType,
+			"config":       types.MapType{ElemType: types.StringType},
+			"location":     types.StringType,
+			"content_type": types.StringType,
+		},
+	}
+}
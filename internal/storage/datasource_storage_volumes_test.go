@@ -0,0 +1,99 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageVolumesDataSource_filters(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName1 := petname.Generate(2, "-")
+	volumeName2 := petname.Generate(2, "-")
+	team := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumesDataSource_filters(poolName, volumeName1, volumeName2, team),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", "storage_volumes.%", "2"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", fmt.Sprintf("storage_volumes.custom/%s.name", volumeName1), volumeName1),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", fmt.Sprintf("storage_volumes.custom/%s.type", volumeName1), "custom"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", fmt.Sprintf("storage_volumes.custom/%s.content_type", volumeName1), "filesystem"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", fmt.Sprintf("storage_volumes.custom/%s.description", volumeName1), "Web data"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", fmt.Sprintf("storage_volumes.custom/%s.name", volumeName2), volumeName2),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.custom", fmt.Sprintf("storage_volumes.custom/%s.content_type", volumeName2), "block"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.team", "storage_volumes.%", "1"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.team", fmt.Sprintf("storage_volumes.custom/%s.config.user.team", volumeName1), team),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.by_name", "storage_volumes.%", "1"),
+					resource.TestCheckResourceAttr("data.incus_storage_volumes.by_name", fmt.Sprintf("storage_volumes.custom/%s.name", volumeName2), volumeName2),
+				),
+			},
+		},
+	})
+}
+
+func testAccStorageVolumesDataSource_filters(poolName, volumeName1, volumeName2, team string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%[1]s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name        = "%[2]s"
+  pool        = incus_storage_pool.pool1.name
+  description = "Web data"
+
+  config = {
+    "user.team" = "%[4]s"
+  }
+}
+
+resource "incus_storage_volume" "volume2" {
+  name         = "%[3]s"
+  pool         = incus_storage_pool.pool1.name
+  content_type = "block"
+}
+
+data "incus_storage_volumes" "custom" {
+  storage_pool = incus_storage_pool.pool1.name
+  filters      = ["type=custom"]
+
+  depends_on = [
+    incus_storage_volume.volume1,
+    incus_storage_volume.volume2,
+  ]
+}
+
+data "incus_storage_volumes" "team" {
+  storage_pool = incus_storage_pool.pool1.name
+
+  config = {
+    "user.team" = "%[4]s"
+  }
+
+  depends_on = [
+    incus_storage_volume.volume1,
+    incus_storage_volume.volume2,
+  ]
+}
+
+data "incus_storage_volumes" "by_name" {
+  storage_pool = incus_storage_pool.pool1.name
+  filters      = ["name=%[3]s"]
+
+  depends_on = [
+    incus_storage_volume.volume1,
+    incus_storage_volume.volume2,
+  ]
+}
+`, poolName, volumeName1, volumeName2, team)
+}
@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// storageBucketKeyPrivateKey is the key of the private data holding the
+// location of the ephemeral storage bucket key.
+const storageBucketKeyPrivateKey = "storage_bucket_key"
+
+type StorageBucketKeyEphemeralModel struct {
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Pool          types.String `tfsdk:"pool"`
+	StorageBucket types.String `tfsdk:"storage_bucket"`
+	Role          types.String `tfsdk:"role"`
+	Project       types.String `tfsdk:"project"`
+	Remote        types.String `tfsdk:"remote"`
+
+	// Computed.
+	AccessKey types.String `tfsdk:"access_key"`
+	SecretKey types.String `tfsdk:"secret_key"`
+}
+
+// storageBucketKeyPrivateData identifies the storage bucket key to delete
+// once the ephemeral resource is closed.
+type storageBucketKeyPrivateData struct {
+	Remote        string `json:"remote"`
+	Project       string `json:"project"`
+	Pool          string `json:"pool"`
+	StorageBucket string `json:"storage_bucket"`
+	Name          string `json:"name"`
+}
+
+// StorageBucketKeyEphemeralResource represent Incus storage bucket key
+// ephemeral resource. The key only exists for the duration of a Terraform
+// run, so that its credentials are never stored in the state.
+type StorageBucketKeyEphemeralResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewStorageBucketKeyEphemeralResource returns a new storage bucket key
+// ephemeral resource.
+func NewStorageBucketKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &StorageBucketKeyEphemeralResource{}
+}
+
+func (r *StorageBucketKeyEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_bucket_key", req.ProviderTypeName)
+}
+
+func (r *StorageBucketKeyEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+
+			"pool": schema.StringAttribute{
+				Required: true,
+			},
+
+			"storage_bucket": schema.StringAttribute{
+				Required: true,
+			},
+
+			"role": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("admin", "read-only"),
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+			},
+
+			// Computed.
+
+			"access_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"secret_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (r *StorageBucketKeyEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r *StorageBucketKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config StorageBucketKeyEphemeralModel
+
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := config.Remote.ValueString()
+	project := config.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := config.Pool.ValueString()
+	bucketName := config.StorageBucket.ValueString()
+
+	// A random name prevents conflicts between concurrent runs, as well
+	// as with keys left behind by runs that were interrupted.
+	keyName := config.Name.ValueString()
+	if keyName == "" {
+		keyName, err = randomStorageBucketKeyName()
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to generate storage bucket key name", err.Error())
+			return
+		}
+	}
+
+	role := config.Role.ValueString()
+	if role == "" {
+		role = "read-only"
+	}
+
+	key := api.StorageBucketKeysPost{
+		StorageBucketKeyPut: api.StorageBucketKeyPut{
+			Description: config.Description.ValueString(),
+			Role:        role,
+		},
+		Name: keyName,
+	}
+
+	createdKey, err := server.CreateStoragePoolBucketKey(poolName, bucketName, key)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create storage bucket key %q of %q", keyName, bucketName), err.Error())
+		return
+	}
+
+	privateData, err := json.Marshal(storageBucketKeyPrivateData{
+		Remote:        remote,
+		Project:       project,
+		Pool:          poolName,
+		StorageBucket: bucketName,
+		Name:          keyName,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to encode storage bucket key %q of %q", keyName, bucketName), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, storageBucketKeyPrivateKey, privateData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Name = types.StringValue(keyName)
+	config.Role = types.StringValue(role)
+	config.AccessKey = types.StringValue(createdKey.AccessKey)
+	config.SecretKey = types.StringValue(createdKey.SecretKey)
+
+	diags = resp.Result.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Close deletes the storage bucket key at the end of the run.
+func (r *StorageBucketKeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateData, diags := req.Private.GetKey(ctx, storageBucketKeyPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateData == nil {
+		return
+	}
+
+	var data storageBucketKeyPrivateData
+	err := json.Unmarshal(privateData, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to decode storage bucket key", err.Error())
+		return
+	}
+
+	server, err := r.provider.InstanceServer(data.Remote, data.Project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	err = server.DeleteStoragePoolBucketKey(data.Pool, data.StorageBucket, data.Name)
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete storage bucket key %q of bucket %q", data.Name, data.StorageBucket), err.Error())
+	}
+}
+
+// randomStorageBucketKeyName returns a random name for an ephemeral storage
+// bucket key.
+func randomStorageBucketKeyName() (string, error) {
+	suffix := make([]byte, 8)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return "", err
+	}
+
+	return "terraform-" + hex.EncodeToString(suffix), nil
+}
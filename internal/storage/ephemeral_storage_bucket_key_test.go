@@ -0,0 +1,67 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageBucketKeyEphemeral_basic(t *testing.T) {
+	bucketName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageBucketKeyEphemeral_basic(bucketName),
+			},
+			{
+				Config: testAccStorageBucketKeyEphemeral_echo(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.key1", "data.storage_bucket", bucketName),
+					resource.TestCheckResourceAttr("echo.key1", "data.pool", "default"),
+					resource.TestCheckResourceAttr("echo.key1", "data.role", "admin"),
+					resource.TestCheckResourceAttrSet("echo.key1", "data.name"),
+					resource.TestCheckResourceAttrSet("echo.key1", "data.access_key"),
+					resource.TestCheckResourceAttrSet("echo.key1", "data.secret_key"),
+				),
+			},
+		},
+	})
+}
+
+func testAccStorageBucketKeyEphemeral_basic(bucketName string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_bucket" "bucket1" {
+  name = "%s"
+  pool = "default"
+}
+`, bucketName)
+}
+
+func testAccStorageBucketKeyEphemeral_echo(bucketName string) string {
+	return fmt.Sprintf(`
+%s
+
+ephemeral "incus_storage_bucket_key" "key1" {
+  pool           = incus_storage_bucket.bucket1.pool
+  storage_bucket = incus_storage_bucket.bucket1.name
+  role           = "admin"
+}
+
+provider "echo" {
+  data = ephemeral.incus_storage_bucket_key.key1
+}
+
+resource "echo" "key1" {}
+`, testAccStorageBucketKeyEphemeral_basic(bucketName))
+}
@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// StorageVolumeListModel contains the filters of the storage volume list resource.
+type StorageVolumeListModel struct {
+	Project     types.String `tfsdk:"project"`
+	Remote      types.String `tfsdk:"remote"`
+	Pool        types.String `tfsdk:"pool"`
+	ContentType types.String `tfsdk:"content_type"`
+	Config      types.Map    `tfsdk:"config"`
+}
+
+// StorageVolumeListResource lists Incus custom storage volumes.
+type StorageVolumeListResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewStorageVolumeListResource returns a new storage volume list resource.
+func NewStorageVolumeListResource() list.ListResource {
+	return &StorageVolumeListResource{}
+}
+
+func (r StorageVolumeListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_volume", req.ProviderTypeName)
+}
+
+func (r StorageVolumeListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	attributes := common.ListSchemaAttributes()
+
+	attributes["pool"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list volumes of the given storage pool. Volumes of all pools are listed by default.",
+	}
+
+	attributes["content_type"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "Only list volumes with the given content type.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("filesystem", "block", "iso"),
+		},
+	}
+
+	attributes["config"] = schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		Description: "Only list volumes whose configuration contains all the given key/value pairs.",
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (r *StorageVolumeListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+func (r StorageVolumeListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filter StorageVolumeListModel
+
+	diags := req.Config.Get(ctx, &filter)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	configFilter, diags := common.ToConfigMap(ctx, filter.Config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	remote := filter.Remote.ValueString()
+	project := filter.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		diags.Append(errors.NewInstanceServerError(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var pools []string
+	if filter.Pool.ValueString() != "" {
+		pools = []string{filter.Pool.ValueString()}
+	} else {
+		pools, err = server.GetStoragePoolNames()
+		if err != nil {
+			diags.AddError("Failed to retrieve storage pools", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	volumeResource := StorageVolumeResource{provider: r.provider}
+	syncState := func(ctx context.Context, state *tfsdk.State, m StorageVolumeModel) diag.Diagnostics {
+		return volumeResource.SyncState(ctx, state, server, m)
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, pool := range pools {
+			volumes, err := server.GetStoragePoolVolumes(pool)
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError(fmt.Sprintf("Failed to retrieve volumes of storage pool %q", pool), err.Error())
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+
+			for _, volume := range volumes {
+				if req.Limit > 0 && count >= req.Limit {
+					return
+				}
+
+				// Only custom volumes can be imported.
+				if volume.Type != "custom" {
+					continue
+				}
+
+				if !common.MatchFilter(filter.ContentType, volume.ContentType) || !common.MatchConfig(volume.Config, configFilter) {
+					continue
+				}
+
+				fields := map[string]string{
+					"pool":    pool,
+					"name":    volume.Name,
+					"type":    volume.Type,
+					"project": project,
+					"remote":  remote,
+				}
+
+				displayName := fmt.Sprintf("%s/%s", pool, volume.Name)
+				result, ok := common.NewListResult(ctx, req, displayName, fields, syncState)
+				if !ok {
+					continue
+				}
+
+				count++
+				if !push(result) {
+					return
+				}
+			}
+		}
+	}
+}
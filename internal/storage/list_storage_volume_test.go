@@ -0,0 +1,90 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageVolumeList_pool(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName1 := petname.Generate(2, "-")
+	volumeName2 := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeList_resources(poolName, volumeName1, volumeName2),
+			},
+			{
+				Query:  true,
+				Config: testAccStorageVolumeList_pool(poolName),
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectLength("incus_storage_volume.all", 2),
+					querycheck.ExpectLength("incus_storage_volume.block", 1),
+					querycheck.ExpectIdentity("incus_storage_volume.block", map[string]knownvalue.Check{
+						"pool":    knownvalue.StringExact(poolName),
+						"name":    knownvalue.StringExact(volumeName2),
+						"project": knownvalue.Null(),
+						"remote":  knownvalue.Null(),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func testAccStorageVolumeList_resources(poolName, volumeName1, volumeName2 string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume" "volume2" {
+  name         = "%s"
+  pool         = incus_storage_pool.pool1.name
+  content_type = "block"
+}
+`, poolName, volumeName1, volumeName2)
+}
+
+func testAccStorageVolumeList_pool(poolName string) string {
+	return fmt.Sprintf(`
+provider "incus" {}
+
+list "incus_storage_volume" "all" {
+  provider = incus
+
+  config {
+    pool = "%[1]s"
+  }
+}
+
+list "incus_storage_volume" "block" {
+  provider = incus
+
+  config {
+    pool         = "%[1]s"
+    content_type = "block"
+  }
+}
+`, poolName)
+}
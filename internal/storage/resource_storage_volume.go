@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -45,6 +46,8 @@ type StorageVolumeModel struct {
 	SourceFile   types.String `tfsdk:"source_file"`
 	Files        types.Set    `tfsdk:"file"`
 
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+
 	// Computed.
 	Location types.String `tfsdk:"location"`
 }
@@ -69,7 +72,11 @@ func (r StorageVolumeResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = fmt.Sprintf("%s_storage_volume", req.ProviderTypeName)
 }
 
-func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r StorageVolumeResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = common.IdentitySchema("pool", "name")
+}
+
+func (r StorageVolumeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
@@ -214,6 +221,8 @@ func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaReques
 		},
 
 		Blocks: map[string]schema.Block{
+			"timeouts": common.TimeoutsBlock(ctx),
+
 			"file": schema.SetNestedBlock{
 				Description: "Upload file to storage volume",
 				NestedObject: schema.NestedBlockObject{
@@ -259,6 +268,38 @@ func (r StorageVolumeResource) Schema(_ context.Context, _ resource.SchemaReques
 							Computed: true,
 							Default:  booldefault.StaticBool(false),
 						},
+
+						"track_drift": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						// Template is here just to satisfy the IncusFile model.
+						"template": schema.BoolAttribute{
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+						},
+
+						"recursive": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"include": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+
+						"exclude": schema.ListAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+
+						"delete_extraneous": schema.BoolAttribute{
+							Optional: true,
+						},
+
+						"source_hash": schema.StringAttribute{
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -281,6 +322,29 @@ func (r *StorageVolumeResource) Configure(_ context.Context, req resource.Config
 	r.provider = provider
 }
 
+func (r StorageVolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// If resource is being destroyed req.Plan will be null.
+	// In such case there is no need for plan modification.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Compute the hashes of the directories uploaded recursively.
+	var files types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file"), &files)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, diags := common.PlanFileSourceHashes(ctx, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file"), files)...)
+}
+
 func (r StorageVolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan StorageVolumeModel
 
@@ -290,17 +354,24 @@ func (r StorageVolumeResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	if !plan.SourceVolume.IsNull() {
-		r.copyStoragePoolVolume(ctx, resp, &plan)
+	createTimeout, diags := plan.Timeouts.Create(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !plan.SourceFile.IsNull() {
+	ctx, cancel := common.ContextWithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if !plan.SourceVolume.IsNull() {
+		r.copyStoragePoolVolume(ctx, resp, &plan)
+	} else if !plan.SourceFile.IsNull() {
 		r.importStoragePoolVolume(ctx, resp, &plan)
-		return
+	} else {
+		r.createStoragePoolVolume(ctx, resp, &plan)
 	}
 
-	r.createStoragePoolVolume(ctx, resp, &plan)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r StorageVolumeResource) createStoragePoolVolume(ctx context.Context, resp *resource.CreateResponse, plan *StorageVolumeModel) {
@@ -393,7 +464,7 @@ func (r StorageVolumeResource) copyStoragePoolVolume(ctx context.Context, resp *
 		return
 	}
 
-	err = opCopy.Wait()
+	err = opCopy.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to copy storage volume %q -> %q", srcVolID, dstVolID), err.Error())
 		return
@@ -475,7 +546,7 @@ func (r StorageVolumeResource) importStoragePoolVolume(ctx context.Context, resp
 		return
 	}
 
-	err = opImport.Wait()
+	err = opImport.WaitContext(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create storage volume from file %q", volName), err.Error())
 		return
@@ -495,6 +566,15 @@ func (r StorageVolumeResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	target := state.Target.ValueString()
@@ -507,6 +587,22 @@ func (r StorageVolumeResource) Read(ctx context.Context, req resource.ReadReques
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, state)
 	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, diags := common.SyncVolumeFiles(ctx, server, state.Pool.ValueString(), state.Type.ValueString(), state.Name.ValueString(), state.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("file"), files)...)
 }
 
 func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -523,6 +619,15 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	remote := plan.Remote.ValueString()
 	project := plan.Project.ValueString()
 	target := plan.Target.ValueString()
@@ -581,8 +686,13 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 			continue
 		}
 
-		targetPath := f.TargetPath.ValueString()
-		err := common.VolumeFileDelete(server, poolName, volType, volName, targetPath)
+		var err error
+		if f.Recursive.ValueBool() {
+			err = common.VolumeDirectoryDelete(server, poolName, volType, volName, f)
+		} else {
+			err = common.VolumeFileDelete(server, poolName, volType, volName, f.TargetPath.ValueString())
+		}
+
 		if err != nil {
 			resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from volume %q", targetResource), err.Error())
 			return
@@ -607,15 +717,17 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 
 		if contentChanged || permissionsChanged {
 			// Delete the old file first otherwise mode and ownership changes
-			// will not be applied.
-			targetPath := newFile.TargetPath.ValueString()
-			err := common.VolumeFileDelete(server, poolName, volType, volName, targetPath)
-			if err != nil {
-				resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from volume %q", targetResource), err.Error())
-				return
+			// will not be applied. Directories replace each file separately.
+			if !newFile.Recursive.ValueBool() {
+				targetPath := newFile.TargetPath.ValueString()
+				err := common.VolumeFileDelete(server, poolName, volType, volName, targetPath)
+				if err != nil {
+					resp.Diagnostics.AddError(fmt.Sprintf("Failed to delete file from volume %q", targetResource), err.Error())
+					return
+				}
 			}
 
-			err = common.VolumeFileUpload(server, poolName, volType, volName, newFile)
+			err := common.VolumeFileUpload(server, poolName, volType, volName, newFile)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Failed to upload updated file to volume %q", targetResource), err.Error())
 				return
@@ -626,6 +738,7 @@ func (r StorageVolumeResource) Update(ctx context.Context, req resource.UpdateRe
 	// Update Terraform state.
 	diags = r.SyncState(ctx, &resp.State, server, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(common.SetIdentity(ctx, resp.Identity, resp.State)...)
 }
 
 func (r StorageVolumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -637,6 +750,15 @@ func (r StorageVolumeResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := common.ContextWithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	remote := state.Remote.ValueString()
 	project := state.Project.ValueString()
 	target := state.Target.ValueString()
@@ -661,9 +783,9 @@ func (r StorageVolumeResource) ImportState(ctx context.Context, req resource.Imp
 		RequiredFields: []string{"pool", "name"},
 	}
 
-	fields, diag := meta.ParseImportID(req.ID)
-	if diag != nil {
-		resp.Diagnostics.Append(diag)
+	fields, diags := meta.ParseImportRequest(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -0,0 +1,391 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+type StorageVolumeBackupModel struct {
+	Name                 types.String `tfsdk:"name"`
+	Pool                 types.String `tfsdk:"pool"`
+	Volume               types.String `tfsdk:"volume"`
+	VolumeOnly           types.Bool   `tfsdk:"volume_only"`
+	OptimizedStorage     types.Bool   `tfsdk:"optimized_storage"`
+	CompressionAlgorithm types.String `tfsdk:"compression_algorithm"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+	OutputPath           types.String `tfsdk:"output_path"`
+	Project              types.String `tfsdk:"project"`
+	Remote               types.String `tfsdk:"remote"`
+
+	// Computed.
+	CreatedAt types.Int64  `tfsdk:"created_at"`
+	Size      types.Int64  `tfsdk:"size"`
+	SHA256    types.String `tfsdk:"sha256"`
+}
+
+// StorageVolumeBackupResource represent Incus storage volume backup resource.
+type StorageVolumeBackupResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewStorageVolumeBackupResource returns a new storage volume backup resource.
+func NewStorageVolumeBackupResource() resource.Resource {
+	return &StorageVolumeBackupResource{}
+}
+
+func (r StorageVolumeBackupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_volume_backup", req.ProviderTypeName)
+}
+
+func (r StorageVolumeBackupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"pool": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"volume": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"volume_only": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"optimized_storage": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"compression_algorithm": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+				Validators: []validator.String{
+					common.RFC3339Validator{},
+				},
+			},
+
+			"output_path": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"created_at": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"size": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"sha256": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StorageVolumeBackupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// ModifyPlan plans the replacement of a backup whose downloaded file was
+// removed or modified, so that the backup is downloaded again.
+func (r StorageVolumeBackupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	common.PlanBackupFile(ctx, req, resp)
+}
+
+func (r StorageVolumeBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan StorageVolumeBackupModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := plan.Pool.ValueString()
+	volName := plan.Volume.ValueString()
+	backupName := plan.Name.ValueString()
+
+	backupReq := api.StorageVolumeBackupsPost{
+		Name:                 backupName,
+		VolumeOnly:           plan.VolumeOnly.ValueBool(),
+		OptimizedStorage:     plan.OptimizedStorage.ValueBool(),
+		CompressionAlgorithm: plan.CompressionAlgorithm.ValueString(),
+	}
+
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid backup expiry", err.Error())
+		return
+	}
+
+	if expiresAt != nil {
+		backupReq.ExpiresAt = *expiresAt
+	}
+
+	op, err := server.CreateStoragePoolVolumeBackup(poolName, volName, backupReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create backup %q for storage volume %q", backupName, volName), err.Error())
+		return
+	}
+
+	// Download the backup if an output path is provided.
+	download := func(req *incus.BackupFileRequest) (*incus.BackupFileResponse, error) {
+		return server.GetStoragePoolVolumeBackupFile(poolName, volName, backupName, req)
+	}
+
+	plan.Size, plan.SHA256, err = common.DownloadBackupFile(plan.OutputPath, download)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to download backup %q of storage volume %q", backupName, volName), err.Error())
+
+		// Remove the backup, as it is not tracked in the state.
+		op, err := server.DeleteStoragePoolVolumeBackup(poolName, volName, backupName)
+		if err == nil {
+			_ = op.WaitContext(ctx)
+		}
+
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r StorageVolumeBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state StorageVolumeBackupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Verify the downloaded backup file.
+	err = common.SyncBackupFile(state.OutputPath, &state.Size, &state.SHA256)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("output_path"), "Failed to verify backup file", err.Error())
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is a no-op, as every attribute change requires a new backup.
+func (r StorageVolumeBackupResource) Update(_ context.Context, _ resource.UpdateRequest, _ *resource.UpdateResponse) {
+}
+
+// Delete removes the backup from the server. A downloaded backup file is
+// left untouched.
+func (r StorageVolumeBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state StorageVolumeBackupModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := state.Pool.ValueString()
+	volName := state.Volume.ValueString()
+	backupName := state.Name.ValueString()
+
+	op, err := server.DeleteStoragePoolVolumeBackup(poolName, volName, backupName)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	// The backup may have already expired and been removed by Incus.
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove backup %q of storage volume %q", backupName, volName), err.Error())
+	}
+}
+
+func (r StorageVolumeBackupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "storage_volume_backup",
+		RequiredFields: []string{"pool", "volume", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the server's current state for a storage volume backup and
+// updates the provided model. It then applies this updated model as the
+// new state in Terraform.
+func (r StorageVolumeBackupResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m StorageVolumeBackupModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	poolName := m.Pool.ValueString()
+	volName := m.Volume.ValueString()
+	backupName := m.Name.ValueString()
+
+	backup, _, err := server.GetStoragePoolVolumeBackup(poolName, volName, backupName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve backup %q of storage volume %q", backupName, volName), err.Error())
+		return respDiags
+	}
+
+	m.Name = types.StringValue(backup.Name)
+	m.VolumeOnly = types.BoolValue(backup.VolumeOnly)
+	m.OptimizedStorage = types.BoolValue(backup.OptimizedStorage)
+	m.CreatedAt = types.Int64Value(backup.CreatedAt.Unix())
+
+	m.ExpiresAt = common.ToTimestampType(backup.ExpiresAt, m.ExpiresAt)
+
+	return tfState.Set(ctx, &m)
+}
@@ -0,0 +1,152 @@
+package storage_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageVolumeBackup_basic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeBackup_basic(poolName, volumeName, backupName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "name", backupName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "pool", poolName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "volume", volumeName),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "volume_only", "false"),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "optimized_storage", "false"),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_backup.backup1", "created_at"),
+					resource.TestCheckNoResourceAttr("incus_storage_volume_backup.backup1", "size"),
+					resource.TestCheckNoResourceAttr("incus_storage_volume_backup.backup1", "sha256"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeBackup_roundTrip(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+	restoredVolumeName := petname.Generate(2, "-")
+	outputPath := filepath.Join(t.TempDir(), "volume.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeBackup_roundTrip(poolName, volumeName, backupName, restoredVolumeName, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "volume_only", "true"),
+					resource.TestCheckResourceAttr("incus_storage_volume_backup.backup1", "output_path", outputPath),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_backup.backup1", "size"),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_backup.backup1", "sha256"),
+					resource.TestCheckResourceAttr("incus_storage_volume.volume2", "name", restoredVolumeName),
+					resource.TestCheckResourceAttr("incus_storage_volume.volume2", "source_file", outputPath),
+				),
+			},
+			{
+				// Removing the downloaded file results in a new backup.
+				PreConfig: func() {
+					err := os.Remove(outputPath)
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccStorageVolumeBackup_roundTrip(poolName, volumeName, backupName, restoredVolumeName, outputPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("incus_storage_volume_backup.backup1", "size"),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_backup.backup1", "sha256"),
+					acctest.TestCheckFileExists(outputPath),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeBackup_importBasic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	backupName := petname.Generate(2, "-")
+	resourceName := "incus_storage_volume_backup.backup1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeBackup_basic(poolName, volumeName, backupName),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        fmt.Sprintf("/%s/%s/%s", poolName, volumeName, backupName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+			},
+		},
+	})
+}
+
+func testAccStorageVolumeBackup_basic(poolName, volumeName, backupName string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_backup" "backup1" {
+  name   = "%s"
+  pool   = incus_storage_volume.volume1.pool
+  volume = incus_storage_volume.volume1.name
+}
+`, poolName, volumeName, backupName)
+}
+
+func testAccStorageVolumeBackup_roundTrip(poolName, volumeName, backupName, restoredVolumeName, outputPath string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_backup" "backup1" {
+  name        = "%s"
+  pool        = incus_storage_volume.volume1.pool
+  volume      = incus_storage_volume.volume1.name
+  volume_only = true
+  output_path = "%s"
+}
+
+resource "incus_storage_volume" "volume2" {
+  name        = "%s"
+  pool        = incus_storage_pool.pool1.name
+  source_file = incus_storage_volume_backup.backup1.output_path
+}
+`, poolName, volumeName, backupName, outputPath, restoredVolumeName)
+}
@@ -0,0 +1,450 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	incus "github.com/lxc/incus/v7/client"
+	"github.com/lxc/incus/v7/shared/api"
+
+	"github.com/lxc/terraform-provider-incus/internal/common"
+	"github.com/lxc/terraform-provider-incus/internal/errors"
+	provider_config "github.com/lxc/terraform-provider-incus/internal/provider-config"
+)
+
+// storageVolumeSnapshotVolumeType is the only volume type whose snapshots
+// can be managed directly. Snapshots of instance volumes are managed
+// through instance snapshots.
+const storageVolumeSnapshotVolumeType = "custom"
+
+type StorageVolumeSnapshotModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Pool        types.String `tfsdk:"pool"`
+	Volume      types.String `tfsdk:"volume"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Project     types.String `tfsdk:"project"`
+	Remote      types.String `tfsdk:"remote"`
+
+	// Computed.
+	ContentType types.String `tfsdk:"content_type"`
+	CreatedAt   types.Int64  `tfsdk:"created_at"`
+}
+
+// StorageVolumeSnapshotResource represent Incus storage volume snapshot resource.
+type StorageVolumeSnapshotResource struct {
+	provider *provider_config.IncusProviderConfig
+}
+
+// NewStorageVolumeSnapshotResource returns a new storage volume snapshot resource.
+func NewStorageVolumeSnapshotResource() resource.Resource {
+	return &StorageVolumeSnapshotResource{}
+}
+
+func (r StorageVolumeSnapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_storage_volume_snapshot", req.ProviderTypeName)
+}
+
+func (r StorageVolumeSnapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(""),
+			},
+
+			"pool": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"volume": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					common.RFC3339Validator{},
+				},
+			},
+
+			"project": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"remote": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Computed.
+
+			"content_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"created_at": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StorageVolumeSnapshotResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	data := req.ProviderData
+	if data == nil {
+		return
+	}
+
+	provider, ok := data.(*provider_config.IncusProviderConfig)
+	if !ok {
+		resp.Diagnostics.Append(errors.NewProviderDataTypeError(req.ProviderData))
+		return
+	}
+
+	r.provider = provider
+}
+
+// ModifyPlan plans the replacement of a snapshot whose server-side expiry
+// (derived from the volume's "snapshots.expiry") has already passed. Such
+// snapshot is about to be pruned by Incus, so it is recreated rather than
+// left to vanish between plan and apply.
+func (r StorageVolumeSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configExpiresAt types.String
+	var stateExpiresAt types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expires_at"), &configExpiresAt)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expires_at"), &stateExpiresAt)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An explicitly configured expiry is always honored as is.
+	if !configExpiresAt.IsNull() {
+		return
+	}
+
+	expiresAt, err := common.ToTimestamp(stateExpiresAt)
+	if err != nil || expiresAt == nil || expiresAt.After(time.Now()) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created_at"), types.Int64Unknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+}
+
+func (r StorageVolumeSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan StorageVolumeSnapshotModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := plan.Pool.ValueString()
+	volName := plan.Volume.ValueString()
+	snapshotName := plan.Name.ValueString()
+
+	expiresAt, err := common.ToTimestamp(plan.ExpiresAt)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid snapshot expiry", err.Error())
+		return
+	}
+
+	snapshotReq := api.StorageVolumeSnapshotsPost{
+		Name:      snapshotName,
+		ExpiresAt: expiresAt,
+	}
+
+	op, err := server.CreateStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotReq)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to create snapshot %q for storage volume %q", snapshotName, volName), err.Error())
+		return
+	}
+
+	// The description can only be set once the snapshot exists.
+	description := plan.Description.ValueString()
+	if description != "" {
+		snapshot, etag, err := server.GetStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+			return
+		}
+
+		snapshotPut := api.StorageVolumeSnapshotPut{
+			Description: description,
+			ExpiresAt:   snapshot.ExpiresAt,
+		}
+
+		err = server.UpdateStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName, snapshotPut, etag)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to update snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+			return
+		}
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r StorageVolumeSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state StorageVolumeSnapshotModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update renames the snapshot if its name has changed, and then applies
+// the description and expiry.
+func (r StorageVolumeSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan StorageVolumeSnapshotModel
+	var state StorageVolumeSnapshotModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := plan.Remote.ValueString()
+	project := plan.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := plan.Pool.ValueString()
+	volName := plan.Volume.ValueString()
+	snapshotName := state.Name.ValueString()
+	newSnapshotName := plan.Name.ValueString()
+
+	// Rename snapshot.
+	if snapshotName != newSnapshotName {
+		renameReq := api.StorageVolumeSnapshotPost{
+			Name: newSnapshotName,
+		}
+
+		op, err := server.RenameStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName, renameReq)
+		if err == nil {
+			err = op.WaitContext(ctx)
+		}
+
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to rename snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+			return
+		}
+
+		snapshotName = newSnapshotName
+	}
+
+	snapshot, etag, err := server.GetStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to retrieve snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+		return
+	}
+
+	// Keep the current expiry unless a new one is configured.
+	expiresAt := snapshot.ExpiresAt
+	if !plan.ExpiresAt.IsNull() && !plan.ExpiresAt.IsUnknown() {
+		expiresAt, err = common.ToTimestamp(plan.ExpiresAt)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid snapshot expiry", err.Error())
+			return
+		}
+	}
+
+	snapshotPut := api.StorageVolumeSnapshotPut{
+		Description: plan.Description.ValueString(),
+		ExpiresAt:   expiresAt,
+	}
+
+	err = server.UpdateStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName, snapshotPut, etag)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to update snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+		return
+	}
+
+	// Update Terraform state.
+	diags = r.SyncState(ctx, &resp.State, server, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r StorageVolumeSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state StorageVolumeSnapshotModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote := state.Remote.ValueString()
+	project := state.Project.ValueString()
+	server, err := r.provider.InstanceServer(remote, project, "")
+	if err != nil {
+		resp.Diagnostics.Append(errors.NewInstanceServerError(err))
+		return
+	}
+
+	poolName := state.Pool.ValueString()
+	volName := state.Volume.ValueString()
+	snapshotName := state.Name.ValueString()
+
+	op, err := server.DeleteStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+	if err == nil {
+		err = op.WaitContext(ctx)
+	}
+
+	// The snapshot may have already expired and been pruned by Incus.
+	if err != nil && !errors.IsNotFoundError(err) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to remove snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+	}
+}
+
+func (r StorageVolumeSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	meta := common.ImportMetadata{
+		ResourceName:   "storage_volume_snapshot",
+		RequiredFields: []string{"pool", "volume", "name"},
+	}
+
+	fields, diag := meta.ParseImportID(req.ID)
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	for k, v := range fields {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(k), v)...)
+	}
+}
+
+// SyncState fetches the server's current state for a storage volume snapshot
+// and updates the provided model. It then applies this updated model as the
+// new state in Terraform.
+//
+// If the snapshot no longer exists, for example because it has been pruned
+// after reaching its expiry, the resource is removed from the state so that
+// Terraform plans its recreation.
+func (r StorageVolumeSnapshotResource) SyncState(ctx context.Context, tfState *tfsdk.State, server incus.InstanceServer, m StorageVolumeSnapshotModel) diag.Diagnostics {
+	var respDiags diag.Diagnostics
+
+	poolName := m.Pool.ValueString()
+	volName := m.Volume.ValueString()
+	snapshotName := m.Name.ValueString()
+
+	snapshot, _, err := server.GetStoragePoolVolumeSnapshot(poolName, storageVolumeSnapshotVolumeType, volName, snapshotName)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			tfState.RemoveResource(ctx)
+			return nil
+		}
+
+		respDiags.AddError(fmt.Sprintf("Failed to retrieve snapshot %q of storage volume %q", snapshotName, volName), err.Error())
+		return respDiags
+	}
+
+	m.Name = types.StringValue(snapshot.Name)
+	m.Description = types.StringValue(snapshot.Description)
+	m.ContentType = types.StringValue(snapshot.ContentType)
+	m.CreatedAt = types.Int64Value(snapshot.CreatedAt.Unix())
+	m.ExpiresAt = toSnapshotExpiryType(snapshot.ExpiresAt, m.ExpiresAt)
+
+	return tfState.Set(ctx, &m)
+}
+
+// toSnapshotExpiryType converts the snapshot expiry into types.String
+// formatted as RFC 3339. A snapshot that never expires has a null expiry.
+// The current value is kept if it represents the same instant, so that an
+// expiry configured with a time zone offset does not result in a diff.
+func toSnapshotExpiryType(expiresAt *time.Time, current types.String) types.String {
+	if expiresAt == nil {
+		return types.StringNull()
+	}
+
+	return common.ToTimestampType(*expiresAt, current)
+}
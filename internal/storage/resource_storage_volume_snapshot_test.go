@@ -0,0 +1,175 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	petname "github.com/dustinkirkland/golang-petname"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"github.com/lxc/terraform-provider-incus/internal/acctest"
+)
+
+func TestAccStorageVolumeSnapshot_basic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "pool", poolName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "volume", volumeName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "description", ""),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "content_type", "filesystem"),
+					resource.TestCheckNoResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at"),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_snapshot.snapshot1", "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeSnapshot_update(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+	newSnapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "description", ""),
+					resource.TestCheckNoResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at"),
+				),
+			},
+			{
+				Config: testAccStorageVolumeSnapshot_expiry(poolName, volumeName, newSnapshotName, "Renamed snapshot", "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", newSnapshotName),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "description", "Renamed snapshot"),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at", "2099-01-01T00:00:00Z"),
+				),
+			},
+			{
+				// The same instant with a time zone offset is kept as configured.
+				Config: testAccStorageVolumeSnapshot_expiry(poolName, volumeName, newSnapshotName, "Renamed snapshot", "2099-01-01T02:00:00+02:00"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("incus_storage_volume_snapshot.snapshot1", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "expires_at", "2099-01-01T02:00:00+02:00"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeSnapshot_volumeExpiry(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, "1w"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("incus_storage_volume.volume1", "config.snapshots.expiry", "1w"),
+					resource.TestCheckResourceAttr("incus_storage_volume_snapshot.snapshot1", "name", snapshotName),
+					resource.TestCheckResourceAttrSet("incus_storage_volume_snapshot.snapshot1", "expires_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageVolumeSnapshot_importBasic(t *testing.T) {
+	poolName := petname.Generate(2, "-")
+	volumeName := petname.Generate(2, "-")
+	snapshotName := petname.Generate(2, "-")
+	resourceName := "incus_storage_volume_snapshot.snapshot1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, ""),
+			},
+			{
+				ResourceName:                         resourceName,
+				ImportStateId:                        fmt.Sprintf("/%s/%s/%s", poolName, volumeName, snapshotName),
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+			},
+		},
+	})
+}
+
+func testAccStorageVolumeSnapshot_basic(poolName, volumeName, snapshotName, volumeExpiry string) string {
+	volumeConfig := ""
+	if volumeExpiry != "" {
+		volumeConfig = fmt.Sprintf(`
+  config = {
+    "snapshots.expiry" = "%s"
+  }`, volumeExpiry)
+	}
+
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%[1]s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%[2]s"
+  pool = incus_storage_pool.pool1.name
+%[4]s
+}
+
+resource "incus_storage_volume_snapshot" "snapshot1" {
+  name   = "%[3]s"
+  pool   = incus_storage_volume.volume1.pool
+  volume = incus_storage_volume.volume1.name
+}
+`, poolName, volumeName, snapshotName, volumeConfig)
+}
+
+func testAccStorageVolumeSnapshot_expiry(poolName, volumeName, snapshotName, description, expiresAt string) string {
+	return fmt.Sprintf(`
+resource "incus_storage_pool" "pool1" {
+  name   = "%[1]s"
+  driver = "dir"
+}
+
+resource "incus_storage_volume" "volume1" {
+  name = "%[2]s"
+  pool = incus_storage_pool.pool1.name
+}
+
+resource "incus_storage_volume_snapshot" "snapshot1" {
+  name        = "%[3]s"
+  description = "%[4]s"
+  pool        = incus_storage_volume.volume1.pool
+  volume      = incus_storage_volume.volume1.name
+  expires_at  = "%[5]s"
+}
+`, poolName, volumeName, snapshotName, description, expiresAt)
+}
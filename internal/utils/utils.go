@@ -39,6 +39,17 @@ func ContextTimeout(ctx context.Context, def time.Duration) int {
 	return int(def.Seconds())
 }
 
+// ContextDuration returns the time until the context deadline runs out.
+// If the context has no deadline, the default duration is returned.
+func ContextDuration(ctx context.Context, def time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if ok {
+		return time.Until(deadline)
+	}
+
+	return def
+}
+
 // HasAnyPrefix checks whether a value has any of the prefixes.
 func HasAnyPrefix(value string, prefixes []string) bool {
 	for _, p := range prefixes {
@@ -28,6 +28,10 @@ func main() {
 	}
 
 	err := providerserver.Serve(context.Background(), provider.NewIncusProvider(version), opts)
+
+	// Release the resources of the provider once Terraform stopped it.
+	provider.Close()
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}